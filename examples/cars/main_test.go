@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -35,6 +36,12 @@ func (s *sqliteDialectWithErrorTranslation) Translate(err error) error {
 	if strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
 		return gorm.ErrDuplicatedKey
 	}
+	if strings.HasPrefix(err.Error(), "FOREIGN KEY constraint failed") {
+		return gorm.ErrForeignKeyViolated
+	}
+	if strings.HasPrefix(err.Error(), "CHECK constraint failed:") {
+		return gorm.ErrCheckConstraintViolated
+	}
 	// }
 	return err
 }
@@ -207,6 +214,320 @@ func Test_GetVehicle_WithFilters(t *testing.T) {
 	}
 }
 
+func Test_GetPerson_Pagination(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPersonRepository(query)
+	controller := api.NewPersonController(query)
+
+	people := []model.Person{}
+	for _, name := range []string{"Ann", "Bob", "Cam", "Dee", "Eve"} {
+		person, err := repo.Create(ctx, model.Person{Name: name})
+		require.NoError(t, err)
+		people = append(people, *person)
+	}
+
+	testCases := []struct {
+		name     string
+		params   api.GetPersonParams
+		expected []string
+		link     string
+	}{
+		{"Default", api.GetPersonParams{}, []string{"Ann", "Bob", "Cam", "Dee", "Eve"}, ""},
+		{"First page", api.GetPersonParams{Limit: ptr(2)}, []string{"Ann", "Bob"}, `<?limit=2&offset=2>; rel="next"`},
+		{"Middle page", api.GetPersonParams{Limit: ptr(2), Offset: ptr(2)}, []string{"Cam", "Dee"}, `<?limit=2&offset=4>; rel="next", <?limit=2&offset=0>; rel="prev"`},
+		{"Last page", api.GetPersonParams{Limit: ptr(2), Offset: ptr(4)}, []string{"Eve"}, `<?limit=2&offset=2>; rel="prev"`},
+		{"With filters", api.GetPersonParams{Limit: ptr(1), Name: ptr("Bob")}, []string{"Bob"}, ""},
+		{"Cursor", api.GetPersonParams{Limit: ptr(2), Cursor: ptr(int64(people[1].ID))}, []string{"Cam", "Dee"}, fmt.Sprintf(`<?cursor=%v&limit=2>; rel="next"`, people[3].ID)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.GetPerson(ctx, api.GetPersonRequestObject{
+				Params: tc.params,
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitGetPersonResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 200, rec.Code)
+			assert.Equal(t, tc.link, rec.Header().Get("Link"))
+
+			actual := []api.Person{}
+			err = json.Unmarshal(rec.Body.Bytes(), &actual)
+			require.NoError(t, err)
+			names := []string{}
+			for _, person := range actual {
+				names = append(names, person.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func Test_GetPerson_PaginationTotalCount(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewPersonController(query)
+	_, _, _, _ = setupModels(t, query)
+
+	// Act
+	response, err := controller.GetPerson(ctx, api.GetPersonRequestObject{
+		Params: api.GetPersonParams{Limit: ptr(1), Offset: ptr(1)},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetPersonResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
+
+func Test_GetPerson_InvalidLimit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewPersonController(query)
+
+	// Act
+	response, err := controller.GetPerson(ctx, api.GetPersonRequestObject{
+		Params: api.GetPersonParams{Limit: ptr(1001)},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetPersonResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 400, rec.Code)
+}
+
+func Test_GetPerson_OrderBy(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPersonRepository(query)
+	controller := api.NewPersonController(query)
+
+	for _, name := range []string{"Bob", "Ann", "Cam", "Ann"} {
+		_, err := repo.Create(ctx, model.Person{Name: name})
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name     string
+		orderBy  *string
+		expected []int
+	}{
+		{"Default", nil, []int{1, 2, 3, 4}},
+		{"Ascending", ptr("name"), []int{2, 4, 1, 3}},
+		{"Descending", ptr("-name"), []int{3, 1, 2, 4}},
+		{"Multiple", ptr("name,-id"), []int{4, 2, 1, 3}},
+		{"Embedded field", ptr("-created_at,-id"), []int{4, 3, 2, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.GetPerson(ctx, api.GetPersonRequestObject{
+				Params: api.GetPersonParams{OrderBy: tc.orderBy},
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitGetPersonResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 200, rec.Code)
+
+			actual := []api.Person{}
+			err = json.Unmarshal(rec.Body.Bytes(), &actual)
+			require.NoError(t, err)
+			ids := []int{}
+			for _, person := range actual {
+				ids = append(ids, person.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func Test_GetPerson_InvalidOrderBy(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewPersonController(query)
+
+	testCases := []struct {
+		name   string
+		params api.GetPersonParams
+	}{
+		{"Unknown field", api.GetPersonParams{OrderBy: ptr("nope")}},
+		{"Relation", api.GetPersonParams{OrderBy: ptr("vehicles")}},
+		{"With cursor", api.GetPersonParams{OrderBy: ptr("name"), Cursor: ptr(int64(0))}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.GetPerson(ctx, api.GetPersonRequestObject{
+				Params: tc.params,
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitGetPersonResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 400, rec.Code)
+		})
+	}
+}
+
+func Test_PostPerson_Invalid(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewPersonController(query)
+
+	// Act
+	response, err := controller.PostPerson(ctx, api.PostPersonRequestObject{
+		Body: &api.CreatePerson{
+			Name:  "",
+			Email: ptr("bob"),
+			Role:  "passenger",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostPersonResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 400, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	require.NotNil(t, errorResponse.Details)
+	assert.Equal(t, []api.FieldError{
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "name", Message: "is required"},
+		{Field: "role", Message: "must be one of driver, owner"},
+	}, *errorResponse.Details)
+
+	count, err := query.Person.WithContext(ctx).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_PatchPersonID_ValidatesSentFields(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPersonRepository(query)
+	controller := api.NewPersonController(query)
+
+	person, err := repo.Create(ctx, model.Person{Name: "Bob"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		body     api.PatchPersonIDApplicationMergePatchPlusJSONRequestBody
+		expected int
+	}{
+		{"Valid", api.PatchPersonIDApplicationMergePatchPlusJSONRequestBody{"role": "owner"}, 204},
+		{"Invalid", api.PatchPersonIDApplicationMergePatchPlusJSONRequestBody{"email": "bob"}, 400},
+		{"Required", api.PatchPersonIDApplicationMergePatchPlusJSONRequestBody{"name": ""}, 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			response, err := controller.PatchPersonID(ctx, api.PatchPersonIDRequestObject{
+				ID:   int64(person.ID),
+				Body: &tt.body,
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitPatchPersonIDResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+
+	person, err = repo.Get(ctx, int64(person.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", person.Name)
+	assert.Equal(t, "owner", person.Role)
+	assert.Nil(t, person.Email)
+}
+
+func Test_GetPart_FilterOperators(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPartRepository(query)
+	controller := api.NewPartController(query)
+
+	parts := []model.Part{
+		{Name: "Muffler", Cost: 100},
+		{Name: "Exhaust pipe", Cost: 250},
+		{Name: "Spark plug", Cost: 10},
+		{Name: "Exhaust manifold", Cost: 400},
+	}
+	for _, part := range parts {
+		_, err := repo.Create(ctx, part)
+		require.NoError(t, err)
+	}
+	err := repo.Delete(ctx, 4, false, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		params   api.GetPartParams
+		expected []int
+	}{
+		{"Greater than or equal", api.GetPartParams{CostGte: ptr(100)}, []int{1, 2}},
+		{"Less than", api.GetPartParams{CostLt: ptr(100)}, []int{3}},
+		{"Not equal", api.GetPartParams{CostNe: ptr(100)}, []int{2, 3}},
+		{"Like", api.GetPartParams{NameLike: ptr("Exhaust%")}, []int{2}},
+		{"In", api.GetPartParams{IDIn: &[]int{1, 3, 4}}, []int{1, 3}},
+		{"Is null", api.GetPartParams{DeletedAtIsNull: ptr(true)}, []int{1, 2, 3}},
+		{"Combined", api.GetPartParams{CostGt: ptr(10), CostLte: ptr(250)}, []int{1, 2}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.GetPart(ctx, api.GetPartRequestObject{
+				Params: tc.params,
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitGetPartResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 200, rec.Code)
+
+			actual := []api.Part{}
+			err = json.Unmarshal(rec.Body.Bytes(), &actual)
+			require.NoError(t, err)
+			ids := []int{}
+			for _, part := range actual {
+				ids = append(ids, part.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
 func Test_GetVehicleID(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -252,6 +573,7 @@ func Test_PutVehicleID(t *testing.T) {
 		ID: int64(vehicle.ID),
 		Body: &api.UpdateVehicle{
 			VehicleModelID: int(vehicle.VehicleModelID),
+			PersonID:       int(vehicle.PersonID),
 			Vin:            "456",
 		},
 	})
@@ -269,124 +591,414 @@ func Test_PutVehicleID(t *testing.T) {
 	assert.Equal(t, "456", vehicle.Vin)
 }
 
-func Test_DeleteVehicleID(t *testing.T) {
+func Test_VehicleID_IfMatch(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	query := newQuery(t)
-	repo := repository.NewVehicleRepository(query)
 	controller := api.NewVehicleController(query)
 
 	_, _, vehicle, _ := setupModels(t, query)
+	getETag := func() string {
+		response, err := controller.GetVehicleID(ctx, api.GetVehicleIDRequestObject{ID: int64(vehicle.ID)})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, response.VisitGetVehicleIDResponse(rec))
+		require.Equal(t, 200, rec.Code)
+		return rec.Header().Get("ETag")
+	}
+	put := func(ifMatch string) int {
+		response, err := controller.PutVehicleID(ctx, api.PutVehicleIDRequestObject{
+			ID:     int64(vehicle.ID),
+			Params: api.PutVehicleIDParams{IfMatch: &ifMatch},
+			Body: &api.UpdateVehicle{
+				VehicleModelID: int(vehicle.VehicleModelID),
+				PersonID:       int(vehicle.PersonID),
+				Vin:            "456",
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, response.VisitPutVehicleIDResponse(rec))
+		return rec.Code
+	}
+	patch := func(ifMatch string) int {
+		response, err := controller.PatchVehicleID(ctx, api.PatchVehicleIDRequestObject{
+			ID:     int64(vehicle.ID),
+			Params: api.PatchVehicleIDParams{IfMatch: &ifMatch},
+			Body:   &api.PatchVehicleIDApplicationMergePatchPlusJSONRequestBody{"vin": "789"},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, response.VisitPatchVehicleIDResponse(rec))
+		return rec.Code
+	}
+	del := func(ifMatch string) int {
+		response, err := controller.DeleteVehicleID(ctx, api.DeleteVehicleIDRequestObject{
+			ID:     int64(vehicle.ID),
+			Params: api.DeleteVehicleIDParams{IfMatch: &ifMatch},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		require.NoError(t, response.VisitDeleteVehicleIDResponse(rec))
+		return rec.Code
+	}
 
-	// Act
-	response, err := controller.DeleteVehicleID(ctx, api.DeleteVehicleIDRequestObject{
-		ID: int64(vehicle.ID),
-	})
-	require.NoError(t, err)
+	etag := getETag()
+	require.NotEmpty(t, etag)
 
-	// Assert
-	rec := httptest.NewRecorder()
-	err = response.VisitDeleteVehicleIDResponse(rec)
-	require.NoError(t, err)
-	assert.Equal(t, 204, rec.Code)
+	// Act & Assert
+	assert.Equal(t, 204, put(etag))
+	newETag := getETag()
+	assert.NotEqual(t, etag, newETag)
 
-	_, err = repo.Get(ctx, int64(vehicle.ID))
-	require.Error(t, err, "Vehicle was not deleted from database")
+	// The first ETag is stale after the update
+	assert.Equal(t, 412, put(etag))
+	assert.Equal(t, 412, patch(etag))
+	assert.Equal(t, 412, patch("not an etag"))
+
+	// So is the second after a patch
+	assert.Equal(t, 204, patch(newETag))
+	patchedETag := getETag()
+	assert.NotEqual(t, newETag, patchedETag)
+
+	assert.Equal(t, 412, del(etag))
+	assert.Equal(t, 412, del(newETag))
+	assert.Equal(t, 412, del(`W/`+patchedETag))
+	assert.Equal(t, 412, del("not an etag"))
+
+	assert.Equal(t, 204, del(patchedETag))
+	assert.Equal(t, 404, del("*"))
 }
 
-func Test_PostVehicleBatch(t *testing.T) {
+func Test_PutPartID_ZeroValue(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	query := newQuery(t)
-	controller := api.NewVehicleController(query)
-	_, vehicleModel, _, person := setupModels(t, query)
+	repo := repository.NewPartRepository(query)
+	controller := api.NewPartController(query)
+
+	part, err := repo.Create(ctx, model.Part{Name: "Muffler", Cost: 100})
+	require.NoError(t, err)
 
 	// Act
-	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
-		Body: &[]api.CreateVehicle{
-			{
-				Vin:            "456",
-				VehicleModelID: int(vehicleModel.ID),
-				PersonID:       int(person.ID),
-			},
+	response, err := controller.PutPartID(ctx, api.PutPartIDRequestObject{
+		ID: int64(part.ID),
+		Body: &api.UpdatePart{
+			Name: "Muffler",
+			Cost: 0,
 		},
 	})
 	require.NoError(t, err)
 
 	// Assert
 	rec := httptest.NewRecorder()
-	err = response.VisitPostVehicleBatchResponse(rec)
+	err = response.VisitPutPartIDResponse(rec)
 	require.NoError(t, err)
-	assert.Equal(t, 201, rec.Code)
+	assert.Equal(t, 204, rec.Code)
 
-	batchResponse := new(api.BatchVehicleResponse)
-	err = json.Unmarshal(rec.Body.Bytes(), batchResponse)
+	part, err = repo.Get(ctx, int64(part.ID))
 	require.NoError(t, err)
-
-	assert.Nil(t, batchResponse.DeletedCount)
-	assert.Len(t, batchResponse.Created, 1)
-
-	newVehicle := batchResponse.Created[0]
-	assert.Equal(t, "456", newVehicle.Vin)
-	assert.Equal(t, vehicleModel.ID, uint(newVehicle.VehicleModelID))
-	assert.Equal(t, person.ID, uint(newVehicle.PersonID))
+	assert.Equal(t, "Muffler", part.Name)
+	assert.Equal(t, 0, part.Cost)
 }
 
-func Test_PostVehicleBatch_UniqueConstraintViolated(t *testing.T) {
+func Test_PatchPartID(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	query := newQuery(t)
-	controller := api.NewVehicleController(query)
-	_, _, vehicle, _ := setupModels(t, query)
+	repo := repository.NewPartRepository(query)
+	controller := api.NewPartController(query)
+
+	part, err := repo.Create(ctx, model.Part{Name: "Muffler", Cost: 100})
+	require.NoError(t, err)
 
 	// Act
-	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
-		Body: &[]api.CreateVehicle{
-			{
-				Vin:            vehicle.Vin,
-				VehicleModelID: int(vehicle.VehicleModelID),
-				PersonID:       int(vehicle.PersonID),
-			},
-		},
+	response, err := controller.PatchPartID(ctx, api.PatchPartIDRequestObject{
+		ID:   int64(part.ID),
+		Body: &api.PatchPartIDApplicationMergePatchPlusJSONRequestBody{"cost": 0},
 	})
 	require.NoError(t, err)
 
 	// Assert
 	rec := httptest.NewRecorder()
-	err = response.VisitPostVehicleBatchResponse(rec)
+	err = response.VisitPatchPartIDResponse(rec)
 	require.NoError(t, err)
-	assert.Equal(t, 409, rec.Code)
+	assert.Equal(t, 204, rec.Code)
+
+	part, err = repo.Get(ctx, int64(part.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Muffler", part.Name)
+	assert.Equal(t, 0, part.Cost)
 }
 
-func Test_PostVehicleBatch_UniqueConstraintViolatedAndClear(t *testing.T) {
+func Test_PatchPartID_InvalidField(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	query := newQuery(t)
-	controller := api.NewVehicleController(query)
-	_, _, vehicle, _ := setupModels(t, query)
+	repo := repository.NewPartRepository(query)
+	hook := &partUpdateHook{}
+	controller := api.NewPartController(query, api.WithPartBeforeUpdate(hook))
+
+	part, err := repo.Create(ctx, model.Part{Name: "Muffler", Cost: 100})
+	require.NoError(t, err)
 
 	// Act
-	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
-		Params: api.PostVehicleBatchParams{
-			Clear: ptr(true),
-			Force: ptr(true),
-			Vin:   ptr(vehicle.Vin), // delete vehicles matching this VIN
-		},
-		Body: &[]api.CreateVehicle{
-			{
-				Vin:            vehicle.Vin,
-				VehicleModelID: int(vehicle.VehicleModelID),
-				PersonID:       int(vehicle.PersonID),
-			},
-		},
+	response, err := controller.PatchPartID(ctx, api.PatchPartIDRequestObject{
+		ID:   int64(part.ID),
+		Body: &api.PatchPartIDApplicationMergePatchPlusJSONRequestBody{"id": 2},
 	})
 	require.NoError(t, err)
 
 	// Assert
 	rec := httptest.NewRecorder()
-	err = response.VisitPostVehicleBatchResponse(rec)
+	err = response.VisitPatchPartIDResponse(rec)
 	require.NoError(t, err)
-	assert.Equal(t, 201, rec.Code)
+	assert.Equal(t, 400, rec.Code)
+
+	part, err = repo.Get(ctx, int64(part.ID))
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), part.ID)
+	assert.False(t, hook.called, "BeforeUpdatePart was called for an invalid field")
+}
+
+// A hook that records whether it was called
+type partUpdateHook struct {
+	called bool
+}
+
+func (h *partUpdateHook) BeforeUpdatePart(ctx context.Context, id int64, update *model.Part, fields []string) error {
+	h.called = true
+	return nil
+}
+
+func Test_PatchPartID_Null(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPartRepository(query)
+	controller := api.NewPartController(query)
+
+	part, err := repo.Create(ctx, model.Part{Name: "Muffler", Cost: 100})
+	require.NoError(t, err)
+
+	// Act
+	response, err := controller.PatchPartID(ctx, api.PatchPartIDRequestObject{
+		ID:   int64(part.ID),
+		Body: &api.PatchPartIDApplicationMergePatchPlusJSONRequestBody{"name": nil},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPatchPartIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 400, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	require.NotNil(t, errorResponse.Details)
+	assert.Equal(t, []api.FieldError{{Field: "name", Message: "cannot be null"}}, *errorResponse.Details)
+
+	part, err = repo.Get(ctx, int64(part.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Muffler", part.Name)
+}
+
+func Test_DeleteVehicleID(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewVehicleRepository(query)
+	controller := api.NewVehicleController(query)
+
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.DeleteVehicleID(ctx, api.DeleteVehicleIDRequestObject{
+		ID: int64(vehicle.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitDeleteVehicleIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	_, err = repo.Get(ctx, int64(vehicle.ID))
+	require.Error(t, err, "Vehicle was not deleted from database")
+}
+
+func Test_DeleteVehicleID_NotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.DeleteVehicleID(ctx, api.DeleteVehicleIDRequestObject{
+		ID: int64(vehicle.ID) + 1,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitDeleteVehicleIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 404, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "vehicle/not_found", errorResponse.Code)
+}
+
+func Test_VehicleID_NotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+
+	_, vehicleModel, vehicle, person := setupModels(t, query)
+	id := int64(vehicle.ID) + 1
+	update := &api.UpdateVehicle{
+		Vin:            "456",
+		VehicleModelID: int(vehicleModel.ID),
+		PersonID:       int(person.ID),
+	}
+
+	testCases := []struct {
+		name  string
+		visit func(rec *httptest.ResponseRecorder) error
+	}{
+		{"Get", func(rec *httptest.ResponseRecorder) error {
+			response, err := controller.GetVehicleID(ctx, api.GetVehicleIDRequestObject{ID: id})
+			require.NoError(t, err)
+			return response.VisitGetVehicleIDResponse(rec)
+		}},
+		{"Put", func(rec *httptest.ResponseRecorder) error {
+			response, err := controller.PutVehicleID(ctx, api.PutVehicleIDRequestObject{ID: id, Body: update})
+			require.NoError(t, err)
+			return response.VisitPutVehicleIDResponse(rec)
+		}},
+		{"Patch", func(rec *httptest.ResponseRecorder) error {
+			response, err := controller.PatchVehicleID(ctx, api.PatchVehicleIDRequestObject{
+				ID:   id,
+				Body: &api.PatchVehicleIDApplicationMergePatchPlusJSONRequestBody{"vin": "456"},
+			})
+			require.NoError(t, err)
+			return response.VisitPatchVehicleIDResponse(rec)
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			rec := httptest.NewRecorder()
+			err := tc.visit(rec)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, 404, rec.Code)
+		})
+	}
+}
+
+func Test_PostVehicleBatch(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+	_, vehicleModel, _, person := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
+		Body: &[]api.CreateVehicle{
+			{
+				Vin:            "456",
+				VehicleModelID: int(vehicleModel.ID),
+				PersonID:       int(person.ID),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostVehicleBatchResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	batchResponse := new(api.BatchVehicleResponse)
+	err = json.Unmarshal(rec.Body.Bytes(), batchResponse)
+	require.NoError(t, err)
+
+	assert.Nil(t, batchResponse.DeletedCount)
+	assert.Len(t, batchResponse.Created, 1)
+
+	newVehicle := batchResponse.Created[0]
+	assert.Equal(t, "456", newVehicle.Vin)
+	assert.Equal(t, vehicleModel.ID, uint(newVehicle.VehicleModelID))
+	assert.Equal(t, person.ID, uint(newVehicle.PersonID))
+}
+
+func Test_PostVehicleBatch_UniqueConstraintViolated(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
+		Body: &[]api.CreateVehicle{
+			{
+				Vin:            vehicle.Vin,
+				VehicleModelID: int(vehicle.VehicleModelID),
+				PersonID:       int(vehicle.PersonID),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostVehicleBatchResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 409, rec.Code)
+}
+
+func Test_PostVehicleBatch_UniqueConstraintViolatedAndClear(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
+		Params: api.PostVehicleBatchParams{
+			Clear: ptr(true),
+			Force: ptr(true),
+			Vin:   ptr(vehicle.Vin), // delete vehicles matching this VIN
+		},
+		Body: &[]api.CreateVehicle{
+			{
+				Vin:            vehicle.Vin,
+				VehicleModelID: int(vehicle.VehicleModelID),
+				PersonID:       int(vehicle.PersonID),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostVehicleBatchResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
 
 	batchResponse := new(api.BatchVehicleResponse)
 	err = json.Unmarshal(rec.Body.Bytes(), batchResponse)
@@ -401,6 +1013,99 @@ func Test_PostVehicleBatch_UniqueConstraintViolatedAndClear(t *testing.T) {
 	assert.Equal(t, vehicle.PersonID, uint(newVehicle.PersonID))
 }
 
+// Hooks that upper case VINs, record created vehicles, and forbid deletes
+type vehicleHooks struct {
+	created []uint
+}
+
+func (h *vehicleHooks) BeforeCreateVehicle(ctx context.Context, vehicle *model.Vehicle) error {
+	vehicle.Vin = strings.ToUpper(vehicle.Vin)
+	return nil
+}
+
+func (h *vehicleHooks) AfterCreateVehicle(ctx context.Context, vehicle *model.Vehicle) {
+	h.created = append(h.created, vehicle.ID)
+}
+
+func (h *vehicleHooks) BeforeDeleteVehicle(ctx context.Context, id int64) error {
+	return &api.StatusError{StatusCode: 403, Code: "forbidden", Message: "vehicles can't be deleted"}
+}
+
+func Test_VehicleHooks(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewVehicleRepository(query)
+	hooks := &vehicleHooks{}
+	controller := api.NewVehicleController(
+		query,
+		api.WithVehicleBeforeCreate(hooks),
+		api.WithVehicleAfterCreate(hooks),
+		api.WithVehicleBeforeDelete(hooks),
+	)
+	_, vehicleModel, vehicle, person := setupModels(t, query)
+
+	// Act
+	createResponse, err := controller.PostVehicle(ctx, api.PostVehicleRequestObject{
+		Body: &api.CreateVehicle{
+			Vin:            "abc",
+			VehicleModelID: int(vehicleModel.ID),
+			PersonID:       int(person.ID),
+		},
+	})
+	require.NoError(t, err)
+	batchResponse, err := controller.PostVehicleBatch(ctx, api.PostVehicleBatchRequestObject{
+		Body: &[]api.CreateVehicle{
+			{
+				Vin:            "def",
+				VehicleModelID: int(vehicleModel.ID),
+				PersonID:       int(person.ID),
+			},
+		},
+	})
+	require.NoError(t, err)
+	deleteResponse, err := controller.DeleteVehicleID(ctx, api.DeleteVehicleIDRequestObject{
+		ID: int64(vehicle.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = createResponse.VisitPostVehicleResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	created := &api.Vehicle{}
+	err = json.Unmarshal(rec.Body.Bytes(), created)
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", created.Vin)
+
+	rec = httptest.NewRecorder()
+	err = batchResponse.VisitPostVehicleBatchResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	batchCreated := new(api.BatchVehicleResponse)
+	err = json.Unmarshal(rec.Body.Bytes(), batchCreated)
+	require.NoError(t, err)
+	require.Len(t, batchCreated.Created, 1)
+	assert.Equal(t, "DEF", batchCreated.Created[0].Vin)
+	assert.Equal(t, []uint{uint(created.ID), uint(batchCreated.Created[0].ID)}, hooks.created)
+
+	rec = httptest.NewRecorder()
+	err = deleteResponse.VisitDeleteVehicleIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 403, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "vehicle/forbidden", errorResponse.Code)
+
+	_, err = repo.Get(ctx, int64(vehicle.ID))
+	require.NoError(t, err, "Vehicle was deleted from database")
+}
+
 func Test_PostVehicleForSale(t *testing.T) {
 	// Arrange
 	query := newQuery(t)
@@ -414,6 +1119,7 @@ func Test_PostVehicleForSale(t *testing.T) {
 	response, err := controller.PostVehicleForSale(context.Background(), api.PostVehicleForSaleRequestObject{
 		Body: &api.CreateVehicleForSale{
 			VehicleID: vehicleID,
+			Condition: api.ConditionUsed,
 			Amount:    "100.00",
 			Duration:  60,
 		},
@@ -433,6 +1139,7 @@ func Test_PostVehicleForSale(t *testing.T) {
 	assert.Equal(t, 60, vehicleForSale.Duration)
 	assert.NotEqual(t, uint(0), vehicleForSale.ID)
 	assert.Equal(t, vehicleID, vehicleForSale.VehicleID)
+	assert.Equal(t, api.ConditionUsed, vehicleForSale.Condition)
 
 	vehicleForSaleFromDb, err := repo.Get(context.Background(), int64(vehicleForSale.ID))
 	require.NoError(t, err)
@@ -440,6 +1147,399 @@ func Test_PostVehicleForSale(t *testing.T) {
 	assert.Equal(t, vehicleID, int(vehicleForSaleFromDb.VehicleID))
 	assert.Equal(t, time.Duration(60), vehicleForSaleFromDb.Duration)
 	assert.True(t, vehicleForSaleFromDb.Amount.Equal(decimal.NewFromFloat(100.00)))
+	assert.Equal(t, model.ConditionUsed, vehicleForSaleFromDb.Condition)
+}
+
+func Test_PostVehicleForSale_ForeignKeyViolated(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	controller := api.NewVehicleForSaleController(query)
+
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostVehicleForSale(context.Background(), api.PostVehicleForSaleRequestObject{
+		Body: &api.CreateVehicleForSale{
+			VehicleID: int(vehicle.ID) + 1,
+			Condition: api.ConditionUsed,
+			Amount:    "100.00",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostVehicleForSaleResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 409, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "vehicle_for_sale/conflict", errorResponse.Code)
+}
+
+func Test_PostPart_CheckConstraintViolated(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	controller := api.NewPartController(query)
+
+	// Act
+	response, err := controller.PostPart(context.Background(), api.PostPartRequestObject{
+		Body: &api.CreatePart{
+			Name: "Muffler",
+			Cost: -1,
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostPartResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 422, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "part/unprocessable", errorResponse.Code)
+}
+
+func Test_PostVehicleForSale_InvalidCondition(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	controller := api.NewVehicleForSaleController(query)
+
+	_, _, vehicle, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostVehicleForSale(context.Background(), api.PostVehicleForSaleRequestObject{
+		Body: &api.CreateVehicleForSale{
+			VehicleID: int(vehicle.ID),
+			Condition: "mint",
+			Amount:    "100.00",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostVehicleForSaleResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 400, rec.Code)
+
+	errorResponse := &api.ErrorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), errorResponse)
+	require.NoError(t, err)
+	require.NotNil(t, errorResponse.Details)
+	assert.Equal(t, []api.FieldError{
+		{Field: "condition", Message: "must be one of new, used, salvage"},
+	}, *errorResponse.Details)
+
+	count, err := query.VehicleForSale.WithContext(context.Background()).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_GetVehicleModel_FilterEnum(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleModelController(query)
+
+	manufacturer, _, _, _ := setupModels(t, query)
+	_, err := repository.NewVehicleModelRepository(query).Create(ctx, model.VehicleModel{
+		ManufacturerID: manufacturer.ID,
+		Name:           "Outlander",
+		Drivetrain:     ptr(model.DrivetrainAllWheel),
+	})
+	require.NoError(t, err)
+
+	// Act
+	response, err := controller.GetVehicleModel(ctx, api.GetVehicleModelRequestObject{
+		Params: api.GetVehicleModelParams{
+			Drivetrain: ptr(int(model.DrivetrainAllWheel)),
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetVehicleModelResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	vehicleModels := []api.VehicleModel{}
+	err = json.Unmarshal(rec.Body.Bytes(), &vehicleModels)
+	require.NoError(t, err)
+	require.Len(t, vehicleModels, 1)
+	assert.Equal(t, ptr(api.DrivetrainAllWheel), vehicleModels[0].Drivetrain)
+}
+
+func Test_GetVehicleID_Expand(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+
+	manufacturer, vehicleModel, vehicle, person := setupModels(t, query)
+
+	// Act
+	response, err := controller.GetVehicleID(ctx, api.GetVehicleIDRequestObject{
+		ID: int64(vehicle.ID),
+		Params: api.GetVehicleIDParams{
+			Expand: ptr("vehicle_model.manufacturer,person"),
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetVehicleIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	actual := &api.Vehicle{}
+	err = json.Unmarshal(rec.Body.Bytes(), actual)
+	require.NoError(t, err)
+	assert.Equal(t, vehicleModel.Name, actual.VehicleModel.Name)
+	assert.Equal(t, manufacturer.Name, actual.VehicleModel.Manufacturer.Name)
+	assert.Equal(t, person.Name, actual.Person.Name)
+}
+
+func Test_GetVehicle_Expand(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+
+	_, vehicleModel, _, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.GetVehicle(ctx, api.GetVehicleRequestObject{
+		Params: api.GetVehicleParams{
+			Expand: ptr("vehicle_model"),
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetVehicleResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	vehicles := []api.Vehicle{}
+	err = json.Unmarshal(rec.Body.Bytes(), &vehicles)
+	require.NoError(t, err)
+	require.Len(t, vehicles, 1)
+	assert.Equal(t, vehicleModel.Name, vehicles[0].VehicleModel.Name)
+	// Associations that weren't expanded are left empty
+	assert.Empty(t, vehicles[0].Person.Name)
+}
+
+func Test_GetVehicleID_InvalidExpand(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleController(query)
+
+	_, _, vehicle, _ := setupModels(t, query)
+
+	testCases := []struct {
+		name   string
+		expand string
+	}{
+		{"Unknown association", "nope"},
+		{"Not an association", "vin"},
+		{"Too deep", "vehicle_model.manufacturer.vehicles"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.GetVehicleID(ctx, api.GetVehicleIDRequestObject{
+				ID: int64(vehicle.ID),
+				Params: api.GetVehicleIDParams{
+					Expand: ptr(tc.expand),
+				},
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitGetVehicleIDResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 400, rec.Code)
+		})
+	}
+}
+
+func Test_GetManufacturerVehicles(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewVehicleModelController(query)
+
+	manufacturer, vehicleModel, _, _ := setupModels(t, query)
+	other, err := repository.NewManufacturerRepository(query).Create(ctx, model.Manufacturer{Name: "Toyota"})
+	require.NoError(t, err)
+	_, err = repository.NewVehicleModelRepository(query).Create(ctx, model.VehicleModel{
+		ManufacturerID: other.ID,
+		Name:           "Tacoma",
+	})
+	require.NoError(t, err)
+
+	// Act
+	response, err := controller.GetManufacturerVehicles(ctx, api.GetManufacturerVehiclesRequestObject{
+		ID: int64(manufacturer.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetManufacturerVehiclesResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+
+	vehicleModels := []api.VehicleModel{}
+	err = json.Unmarshal(rec.Body.Bytes(), &vehicleModels)
+	require.NoError(t, err)
+	require.Len(t, vehicleModels, 1)
+	assert.Equal(t, vehicleModel.Name, vehicleModels[0].Name)
+}
+
+func Test_PostManufacturerVehicles(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewVehicleModelRepository(query)
+	controller := api.NewVehicleModelController(query)
+
+	manufacturer, _, _, _ := setupModels(t, query)
+
+	// Act
+	response, err := controller.PostManufacturerVehicles(ctx, api.PostManufacturerVehiclesRequestObject{
+		ID: int64(manufacturer.ID),
+		// The manufacturer is set from the path
+		Body: &api.CreateVehicleModelForManufacturer{
+			Name: "Pajero",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostManufacturerVehiclesResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	actual := &api.VehicleModel{}
+	err = json.Unmarshal(rec.Body.Bytes(), actual)
+	require.NoError(t, err)
+	assert.Equal(t, int(manufacturer.ID), actual.ManufacturerID)
+
+	vehicleModelFromDb, err := repo.Get(ctx, int64(actual.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Pajero", vehicleModelFromDb.Name)
+	assert.Equal(t, manufacturer.ID, vehicleModelFromDb.ManufacturerID)
+}
+
+func Test_PartModels_Association(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	repo := repository.NewPartRepository(query)
+	controller := api.NewPartController(query)
+
+	_, vehicleModel, _, _ := setupModels(t, query)
+	part, err := repo.Create(ctx, model.Part{Name: "Muffler"})
+	require.NoError(t, err)
+
+	// Act
+	putResponse, err := controller.PutPartModel(ctx, api.PutPartModelRequestObject{
+		ID:      int64(part.ID),
+		ModelID: int64(vehicleModel.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = putResponse.VisitPutPartModelResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	getResponse, err := controller.GetPartModels(ctx, api.GetPartModelsRequestObject{
+		ID: int64(part.ID),
+	})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	err = getResponse.VisitGetPartModelsResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	vehicleModels := []api.VehicleModel{}
+	err = json.Unmarshal(rec.Body.Bytes(), &vehicleModels)
+	require.NoError(t, err)
+	require.Len(t, vehicleModels, 1)
+	assert.Equal(t, vehicleModel.Name, vehicleModels[0].Name)
+
+	// Act
+	deleteResponse, err := controller.DeletePartModel(ctx, api.DeletePartModelRequestObject{
+		ID:      int64(part.ID),
+		ModelID: int64(vehicleModel.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec = httptest.NewRecorder()
+	err = deleteResponse.VisitDeletePartModelResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	models, err := repo.ListModels(ctx, int64(part.ID))
+	require.NoError(t, err)
+	assert.Empty(t, models)
+
+	// Only the association was removed
+	_, err = repository.NewVehicleModelRepository(query).Get(ctx, int64(vehicleModel.ID))
+	require.NoError(t, err)
+}
+
+func Test_PutPartModel_NotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	query := newQuery(t)
+	controller := api.NewPartController(query)
+
+	_, vehicleModel, _, _ := setupModels(t, query)
+	part, err := repository.NewPartRepository(query).Create(ctx, model.Part{Name: "Muffler"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		id      int64
+		modelID int64
+	}{
+		{"Missing part", int64(part.ID) + 1, int64(vehicleModel.ID)},
+		{"Missing model", int64(part.ID), int64(vehicleModel.ID) + 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			response, err := controller.PutPartModel(ctx, api.PutPartModelRequestObject{
+				ID:      tc.id,
+				ModelID: tc.modelID,
+			})
+			require.NoError(t, err)
+
+			// Assert
+			rec := httptest.NewRecorder()
+			err = response.VisitPutPartModelResponse(rec)
+			require.NoError(t, err)
+			assert.Equal(t, 404, rec.Code)
+		})
+	}
 }
 
 func ptr[T any](val T) *T {
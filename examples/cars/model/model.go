@@ -20,12 +20,14 @@ type VehicleModel struct {
 	Name           string
 	ManufacturerID uint
 	Manufacturer   Manufacturer
+	Drivetrain     *Drivetrain
 	Parts          []Part `gorm:"many2many:vehicle_parts;"`
 }
 
 // An individual of a model, like Joe's Chevrolet Silverado
 type Vehicle struct {
 	gorm.Model
+	// The vehicle identification number
 	Vin            string `gorm:"uniqueIndex"`
 	VehicleModelID uint
 	VehicleModel   VehicleModel
@@ -33,11 +35,30 @@ type Vehicle struct {
 	Person         Person
 }
 
+// The condition of a vehicle for sale
+type Condition string
+
+const (
+	ConditionNew     Condition = "new"
+	ConditionUsed    Condition = "used"
+	ConditionSalvage Condition = "salvage"
+)
+
+// How many wheels a vehicle model drives
+type Drivetrain int
+
+const (
+	DrivetrainTwoWheel Drivetrain = iota + 1
+	DrivetrainFourWheel
+	DrivetrainAllWheel
+)
+
 // A vehicle for sale
 type VehicleForSale struct {
 	gorm.Model
 	VehicleID uint
 	Vehicle   Vehicle
+	Condition Condition
 	Amount    decimal.Decimal `goalesce:"openapi_type:string;map:MapVehicleForSaleAmount_Custom;map_api:MapApiVehicleForSaleAmount_Custom" gorm:"type:decimal(10,2);"`
 	Duration  time.Duration   `goalesce:"openapi_type:integer;"`
 }
@@ -69,13 +90,17 @@ func MapApiVehicleForSaleDuration(duration time.Duration) int {
 // A vehicle part for one or more models, like a muffler for all Chevrolet pickups
 type Part struct {
 	gorm.Model
-	Name   string
-	Cost   int
+	Name string `gorm:"size:64;not null"`
+	// The cost in cents
+	Cost   int            `gorm:"not null;default:0;check:cost >= 0"`
 	Models []VehicleModel `gorm:"many2many:model_parts;"`
 }
 
 // A person, who may drive a vehicle
 type Person struct {
 	gorm.Model
-	Name string
+	Name     string  `gorm:"size:128" validate:"required,max=128"`
+	Nickname *string `gorm:"size:32;not null;default:''"`
+	Email    *string `validate:"omitempty,email"`
+	Role     string  `gorm:"default:driver" validate:"omitempty,oneof=driver owner"`
 }
@@ -71,7 +71,7 @@ func newPart(db *gorm.DB, opts ...gen.DOOption) part {
 }
 
 type part struct {
-	partDo
+	partDo partDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -109,6 +109,14 @@ func (p *part) updateTableName(table string) *part {
 	return p
 }
 
+func (p *part) WithContext(ctx context.Context) IPartDo { return p.partDo.WithContext(ctx) }
+
+func (p part) TableName() string { return p.partDo.TableName() }
+
+func (p part) Alias() string { return p.partDo.Alias() }
+
+func (p part) Columns(cols ...field.Expr) gen.Columns { return p.partDo.Columns(cols...) }
+
 func (p *part) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := p.fieldMap[fieldName]
 	if !ok || _f == nil {
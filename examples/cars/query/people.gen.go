@@ -32,6 +32,9 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 	_person.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_person.DeletedAt = field.NewField(tableName, "deleted_at")
 	_person.Name = field.NewString(tableName, "name")
+	_person.Nickname = field.NewString(tableName, "nickname")
+	_person.Email = field.NewString(tableName, "email")
+	_person.Role = field.NewString(tableName, "role")
 
 	_person.fillFieldMap()
 
@@ -39,7 +42,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 }
 
 type person struct {
-	personDo
+	personDo personDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -47,6 +50,9 @@ type person struct {
 	UpdatedAt field.Time
 	DeletedAt field.Field
 	Name      field.String
+	Nickname  field.String
+	Email     field.String
+	Role      field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -68,12 +74,23 @@ func (p *person) updateTableName(table string) *person {
 	p.UpdatedAt = field.NewTime(table, "updated_at")
 	p.DeletedAt = field.NewField(table, "deleted_at")
 	p.Name = field.NewString(table, "name")
+	p.Nickname = field.NewString(table, "nickname")
+	p.Email = field.NewString(table, "email")
+	p.Role = field.NewString(table, "role")
 
 	p.fillFieldMap()
 
 	return p
 }
 
+func (p *person) WithContext(ctx context.Context) IPersonDo { return p.personDo.WithContext(ctx) }
+
+func (p person) TableName() string { return p.personDo.TableName() }
+
+func (p person) Alias() string { return p.personDo.Alias() }
+
+func (p person) Columns(cols ...field.Expr) gen.Columns { return p.personDo.Columns(cols...) }
+
 func (p *person) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := p.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -84,12 +101,15 @@ func (p *person) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (p *person) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 5)
+	p.fieldMap = make(map[string]field.Expr, 8)
 	p.fieldMap["id"] = p.ID
 	p.fieldMap["created_at"] = p.CreatedAt
 	p.fieldMap["updated_at"] = p.UpdatedAt
 	p.fieldMap["deleted_at"] = p.DeletedAt
 	p.fieldMap["name"] = p.Name
+	p.fieldMap["nickname"] = p.Nickname
+	p.fieldMap["email"] = p.Email
+	p.fieldMap["role"] = p.Role
 }
 
 func (p person) clone(db *gorm.DB) person {
@@ -32,6 +32,7 @@ func newVehicleForSale(db *gorm.DB, opts ...gen.DOOption) vehicleForSale {
 	_vehicleForSale.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_vehicleForSale.DeletedAt = field.NewField(tableName, "deleted_at")
 	_vehicleForSale.VehicleID = field.NewUint(tableName, "vehicle_id")
+	_vehicleForSale.Condition = field.NewString(tableName, "condition")
 	_vehicleForSale.Amount = field.NewField(tableName, "amount")
 	_vehicleForSale.Duration = field.NewInt64(tableName, "duration")
 	_vehicleForSale.Vehicle = vehicleForSaleBelongsToVehicle{
@@ -94,7 +95,7 @@ func newVehicleForSale(db *gorm.DB, opts ...gen.DOOption) vehicleForSale {
 }
 
 type vehicleForSale struct {
-	vehicleForSaleDo
+	vehicleForSaleDo vehicleForSaleDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -102,6 +103,7 @@ type vehicleForSale struct {
 	UpdatedAt field.Time
 	DeletedAt field.Field
 	VehicleID field.Uint
+	Condition field.String
 	Amount    field.Field
 	Duration  field.Int64
 	Vehicle   vehicleForSaleBelongsToVehicle
@@ -126,6 +128,7 @@ func (v *vehicleForSale) updateTableName(table string) *vehicleForSale {
 	v.UpdatedAt = field.NewTime(table, "updated_at")
 	v.DeletedAt = field.NewField(table, "deleted_at")
 	v.VehicleID = field.NewUint(table, "vehicle_id")
+	v.Condition = field.NewString(table, "condition")
 	v.Amount = field.NewField(table, "amount")
 	v.Duration = field.NewInt64(table, "duration")
 
@@ -134,6 +137,18 @@ func (v *vehicleForSale) updateTableName(table string) *vehicleForSale {
 	return v
 }
 
+func (v *vehicleForSale) WithContext(ctx context.Context) IVehicleForSaleDo {
+	return v.vehicleForSaleDo.WithContext(ctx)
+}
+
+func (v vehicleForSale) TableName() string { return v.vehicleForSaleDo.TableName() }
+
+func (v vehicleForSale) Alias() string { return v.vehicleForSaleDo.Alias() }
+
+func (v vehicleForSale) Columns(cols ...field.Expr) gen.Columns {
+	return v.vehicleForSaleDo.Columns(cols...)
+}
+
 func (v *vehicleForSale) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := v.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -144,12 +159,13 @@ func (v *vehicleForSale) GetFieldByName(fieldName string) (field.OrderExpr, bool
 }
 
 func (v *vehicleForSale) fillFieldMap() {
-	v.fieldMap = make(map[string]field.Expr, 8)
+	v.fieldMap = make(map[string]field.Expr, 9)
 	v.fieldMap["id"] = v.ID
 	v.fieldMap["created_at"] = v.CreatedAt
 	v.fieldMap["updated_at"] = v.UpdatedAt
 	v.fieldMap["deleted_at"] = v.DeletedAt
 	v.fieldMap["vehicle_id"] = v.VehicleID
+	v.fieldMap["condition"] = v.Condition
 	v.fieldMap["amount"] = v.Amount
 	v.fieldMap["duration"] = v.Duration
 
@@ -33,6 +33,7 @@ func newVehicleModel(db *gorm.DB, opts ...gen.DOOption) vehicleModel {
 	_vehicleModel.DeletedAt = field.NewField(tableName, "deleted_at")
 	_vehicleModel.Name = field.NewString(tableName, "name")
 	_vehicleModel.ManufacturerID = field.NewUint(tableName, "manufacturer_id")
+	_vehicleModel.Drivetrain = field.NewInt(tableName, "drivetrain")
 	_vehicleModel.Manufacturer = vehicleModelBelongsToManufacturer{
 		db: db.Session(&gorm.Session{}),
 
@@ -83,7 +84,7 @@ func newVehicleModel(db *gorm.DB, opts ...gen.DOOption) vehicleModel {
 }
 
 type vehicleModel struct {
-	vehicleModelDo
+	vehicleModelDo vehicleModelDo
 
 	ALL            field.Asterisk
 	ID             field.Uint
@@ -92,6 +93,7 @@ type vehicleModel struct {
 	DeletedAt      field.Field
 	Name           field.String
 	ManufacturerID field.Uint
+	Drivetrain     field.Int
 	Manufacturer   vehicleModelBelongsToManufacturer
 
 	Parts vehicleModelManyToManyParts
@@ -117,12 +119,25 @@ func (v *vehicleModel) updateTableName(table string) *vehicleModel {
 	v.DeletedAt = field.NewField(table, "deleted_at")
 	v.Name = field.NewString(table, "name")
 	v.ManufacturerID = field.NewUint(table, "manufacturer_id")
+	v.Drivetrain = field.NewInt(table, "drivetrain")
 
 	v.fillFieldMap()
 
 	return v
 }
 
+func (v *vehicleModel) WithContext(ctx context.Context) IVehicleModelDo {
+	return v.vehicleModelDo.WithContext(ctx)
+}
+
+func (v vehicleModel) TableName() string { return v.vehicleModelDo.TableName() }
+
+func (v vehicleModel) Alias() string { return v.vehicleModelDo.Alias() }
+
+func (v vehicleModel) Columns(cols ...field.Expr) gen.Columns {
+	return v.vehicleModelDo.Columns(cols...)
+}
+
 func (v *vehicleModel) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := v.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -133,13 +148,14 @@ func (v *vehicleModel) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (v *vehicleModel) fillFieldMap() {
-	v.fieldMap = make(map[string]field.Expr, 8)
+	v.fieldMap = make(map[string]field.Expr, 9)
 	v.fieldMap["id"] = v.ID
 	v.fieldMap["created_at"] = v.CreatedAt
 	v.fieldMap["updated_at"] = v.UpdatedAt
 	v.fieldMap["deleted_at"] = v.DeletedAt
 	v.fieldMap["name"] = v.Name
 	v.fieldMap["manufacturer_id"] = v.ManufacturerID
+	v.fieldMap["drivetrain"] = v.Drivetrain
 
 }
 
@@ -2,6 +2,7 @@ package model
 
 // Note: this does not inherit from gorm.Model
 // See https://github.com/OAI/OpenAPI-Specification/issues/822
+
 type Address struct {
 	ID         int64 `gorm:"primaryKey;autoIncrement:true" json:"id"`
 	City       string
@@ -55,7 +55,7 @@ func newAddress(db *gorm.DB, opts ...gen.DOOption) address {
 }
 
 type address struct {
-	addressDo
+	addressDo addressDo
 
 	ALL        field.Asterisk
 	ID         field.Int64
@@ -87,6 +87,14 @@ func (a *address) updateTableName(table string) *address {
 	return a
 }
 
+func (a *address) WithContext(ctx context.Context) IAddressDo { return a.addressDo.WithContext(ctx) }
+
+func (a address) TableName() string { return a.addressDo.TableName() }
+
+func (a address) Alias() string { return a.addressDo.Alias() }
+
+func (a address) Columns(cols ...field.Expr) gen.Columns { return a.addressDo.Columns(cols...) }
+
 func (a *address) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := a.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -39,7 +39,7 @@ func newCustom(db *gorm.DB, opts ...gen.DOOption) custom {
 }
 
 type custom struct {
-	customDo
+	customDo customDo
 
 	ALL       field.Asterisk
 	ID        field.Int64
@@ -74,6 +74,14 @@ func (c *custom) updateTableName(table string) *custom {
 	return c
 }
 
+func (c *custom) WithContext(ctx context.Context) ICustomDo { return c.customDo.WithContext(ctx) }
+
+func (c custom) TableName() string { return c.customDo.TableName() }
+
+func (c custom) Alias() string { return c.customDo.Alias() }
+
+func (c custom) Columns(cols ...field.Expr) gen.Columns { return c.customDo.Columns(cols...) }
+
 func (c *custom) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := c.fieldMap[fieldName]
 	if !ok || _f == nil {
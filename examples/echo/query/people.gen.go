@@ -39,7 +39,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 }
 
 type person struct {
-	personDo
+	personDo personDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -74,6 +74,14 @@ func (p *person) updateTableName(table string) *person {
 	return p
 }
 
+func (p *person) WithContext(ctx context.Context) IPersonDo { return p.personDo.WithContext(ctx) }
+
+func (p person) TableName() string { return p.personDo.TableName() }
+
+func (p person) Alias() string { return p.personDo.Alias() }
+
+func (p person) Columns(cols ...field.Expr) gen.Columns { return p.personDo.Columns(cols...) }
+
 func (p *person) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := p.fieldMap[fieldName]
 	if !ok || _f == nil {
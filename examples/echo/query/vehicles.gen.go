@@ -78,7 +78,7 @@ func newVehicle(db *gorm.DB, opts ...gen.DOOption) vehicle {
 }
 
 type vehicle struct {
-	vehicleDo
+	vehicleDo vehicleDo
 
 	ALL            field.Asterisk
 	ID             field.Uint
@@ -120,6 +120,14 @@ func (v *vehicle) updateTableName(table string) *vehicle {
 	return v
 }
 
+func (v *vehicle) WithContext(ctx context.Context) IVehicleDo { return v.vehicleDo.WithContext(ctx) }
+
+func (v vehicle) TableName() string { return v.vehicleDo.TableName() }
+
+func (v vehicle) Alias() string { return v.vehicleDo.Alias() }
+
+func (v vehicle) Columns(cols ...field.Expr) gen.Columns { return v.vehicleDo.Columns(cols...) }
+
 func (v *vehicle) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := v.fieldMap[fieldName]
 	if !ok || _f == nil {
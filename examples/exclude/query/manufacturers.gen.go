@@ -70,7 +70,7 @@ func newManufacturer(db *gorm.DB, opts ...gen.DOOption) manufacturer {
 }
 
 type manufacturer struct {
-	manufacturerDo
+	manufacturerDo manufacturerDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -106,6 +106,18 @@ func (m *manufacturer) updateTableName(table string) *manufacturer {
 	return m
 }
 
+func (m *manufacturer) WithContext(ctx context.Context) IManufacturerDo {
+	return m.manufacturerDo.WithContext(ctx)
+}
+
+func (m manufacturer) TableName() string { return m.manufacturerDo.TableName() }
+
+func (m manufacturer) Alias() string { return m.manufacturerDo.Alias() }
+
+func (m manufacturer) Columns(cols ...field.Expr) gen.Columns {
+	return m.manufacturerDo.Columns(cols...)
+}
+
 func (m *manufacturer) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := m.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -83,7 +83,7 @@ func newVehicleModel(db *gorm.DB, opts ...gen.DOOption) vehicleModel {
 }
 
 type vehicleModel struct {
-	vehicleModelDo
+	vehicleModelDo vehicleModelDo
 
 	ALL            field.Asterisk
 	ID             field.Uint
@@ -123,6 +123,18 @@ func (v *vehicleModel) updateTableName(table string) *vehicleModel {
 	return v
 }
 
+func (v *vehicleModel) WithContext(ctx context.Context) IVehicleModelDo {
+	return v.vehicleModelDo.WithContext(ctx)
+}
+
+func (v vehicleModel) TableName() string { return v.vehicleModelDo.TableName() }
+
+func (v vehicleModel) Alias() string { return v.vehicleModelDo.Alias() }
+
+func (v vehicleModel) Columns(cols ...field.Expr) gen.Columns {
+	return v.vehicleModelDo.Columns(cols...)
+}
+
 func (v *vehicleModel) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := v.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -8,7 +8,7 @@ import (
 func main() {
 	g := gen.NewGenerator(gen.Config{
 		OutPath: "query",
-		Mode:    gen.WithoutContext | gen.WithQueryInterface,
+		Mode:    gen.WithQueryInterface,
 	})
 	g.ApplyBasic(model.User{}, model.Skill{})
 	g.Execute()
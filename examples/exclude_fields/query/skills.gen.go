@@ -45,7 +45,7 @@ func newSkill(db *gorm.DB, opts ...gen.DOOption) skill {
 }
 
 type skill struct {
-	skillDo
+	skillDo skillDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -83,6 +83,14 @@ func (s *skill) updateTableName(table string) *skill {
 	return s
 }
 
+func (s *skill) WithContext(ctx context.Context) ISkillDo { return s.skillDo.WithContext(ctx) }
+
+func (s skill) TableName() string { return s.skillDo.TableName() }
+
+func (s skill) Alias() string { return s.skillDo.Alias() }
+
+func (s skill) Columns(cols ...field.Expr) gen.Columns { return s.skillDo.Columns(cols...) }
+
 func (s *skill) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := s.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -0,0 +1,43 @@
+module github.com/joeriddles/goalesce/examples/keys
+
+go 1.20
+
+replace github.com/joeriddles/goalesce => ../..
+
+require (
+	github.com/getkin/kin-openapi v0.126.0
+	github.com/google/uuid v1.5.0
+	github.com/joeriddles/goalesce v0.0.0-00010101000000-000000000000
+	github.com/oapi-codegen/runtime v1.1.1
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.16.0
+	gorm.io/driver/sqlite v1.5.0
+	gorm.io/gen v0.3.26
+	gorm.io/gorm v1.25.11
+	gorm.io/plugin/dbresolver v1.5.2
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-openapi/jsonpointer v0.21.0 // indirect
+	github.com/go-openapi/swag v0.23.0 // indirect
+	github.com/go-sql-driver/mysql v1.8.1 // indirect
+	github.com/invopop/yaml v0.3.1 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/mattn/go-sqlite3 v1.14.16 // indirect
+	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
+	github.com/perimeterx/marshmallow v1.1.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.19.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/tools v0.23.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gorm.io/datatypes v1.2.1 // indirect
+	gorm.io/driver/mysql v1.5.7 // indirect
+	gorm.io/hints v1.1.2 // indirect
+)
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/joeriddles/goalesce/examples/keys/model"
+	"gorm.io/gen"
+)
+
+func main() {
+	g := gen.NewGenerator(gen.Config{
+		OutPath: "query",
+		Mode:    gen.WithQueryInterface,
+	})
+	g.ApplyBasic(
+		model.Account{},
+		model.Country{},
+		model.Membership{},
+		model.Ticket{},
+	)
+	g.Execute()
+}
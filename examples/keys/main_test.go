@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/joeriddles/goalesce/examples/keys/generated/api"
+	"github.com/joeriddles/goalesce/examples/keys/generated/repository"
+	"github.com/joeriddles/goalesce/examples/keys/model"
+	"github.com/joeriddles/goalesce/examples/keys/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newQuery(t *testing.T) *query.Query {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(model.Account{}, model.Country{}, model.Membership{}, model.Ticket{}))
+	query := query.Use(db)
+	return query
+}
+
+func Test_PostAccount_UUID(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewAccountRepository(query)
+	controller := api.NewAccountController(query)
+
+	// Act
+	response, err := controller.PostAccount(context.Background(), api.PostAccountRequestObject{
+		Body: &api.CreateAccount{
+			Name: "Bob",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostAccountResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	account := &api.Account{}
+	err = json.Unmarshal(rec.Body.Bytes(), account)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, account.ID)
+
+	accountFromDb, err := repo.Get(context.Background(), account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", accountFromDb.Name)
+}
+
+func Test_GetAccountID_UUID(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewAccountRepository(query)
+	account, err := repo.Create(context.Background(), model.Account{Name: "Bob"})
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), model.Account{Name: "Jim"})
+	require.NoError(t, err)
+
+	controller := api.NewAccountController(query)
+
+	// Act
+	response, err := controller.GetAccountID(context.Background(), api.GetAccountIDRequestObject{
+		ID: account.ID,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetAccountIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	actual := &api.Account{}
+	err = json.Unmarshal(rec.Body.Bytes(), actual)
+	require.NoError(t, err)
+	assert.Equal(t, account.ID, actual.ID)
+	assert.Equal(t, "Bob", actual.Name)
+}
+
+func Test_PutCountryID_String(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewCountryRepository(query)
+	controller := api.NewCountryController(query)
+
+	response, err := controller.PostCountry(context.Background(), api.PostCountryRequestObject{
+		Body: &api.CreateCountry{
+			Code: "NZ",
+			Name: "New Zealand",
+		},
+	})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	err = response.VisitPostCountryResponse(rec)
+	require.NoError(t, err)
+	require.Equal(t, 201, rec.Code)
+
+	// Act
+	putResponse, err := controller.PutCountryID(context.Background(), api.PutCountryIDRequestObject{
+		ID: "NZ",
+		Body: &api.UpdateCountry{
+			Name: "Aotearoa New Zealand",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec = httptest.NewRecorder()
+	err = putResponse.VisitPutCountryIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	country, err := repo.Get(context.Background(), "NZ")
+	require.NoError(t, err)
+	assert.Equal(t, "Aotearoa New Zealand", country.Name)
+}
+
+func Test_DeleteCountryID_String(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewCountryRepository(query)
+	_, err := repo.Create(context.Background(), model.Country{Code: "NZ", Name: "New Zealand"})
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), model.Country{Code: "AU", Name: "Australia"})
+	require.NoError(t, err)
+
+	controller := api.NewCountryController(query)
+
+	// Act
+	response, err := controller.DeleteCountryID(context.Background(), api.DeleteCountryIDRequestObject{
+		ID: "NZ",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitDeleteCountryIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	_, err = repo.Get(context.Background(), "NZ")
+	require.Error(t, err, "Country was not deleted from database")
+	_, err = repo.Get(context.Background(), "AU")
+	require.NoError(t, err)
+}
+
+func Test_GetCountry_OrderedByKey(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewCountryRepository(query)
+	for _, code := range []string{"NZ", "AU", "FJ"} {
+		_, err := repo.Create(context.Background(), model.Country{Code: code})
+		require.NoError(t, err)
+	}
+
+	controller := api.NewCountryController(query)
+
+	// Act
+	response, err := controller.GetCountry(context.Background(), api.GetCountryRequestObject{})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetCountryResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	countries := []api.Country{}
+	err = json.Unmarshal(rec.Body.Bytes(), &countries)
+	require.NoError(t, err)
+	codes := []string{}
+	for _, country := range countries {
+		codes = append(codes, country.Code)
+	}
+	assert.Equal(t, []string{"AU", "FJ", "NZ"}, codes)
+}
+
+func Test_Membership_CompositeKey(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	account, err := repository.NewAccountRepository(query).Create(context.Background(), model.Account{Name: "Bob"})
+	require.NoError(t, err)
+	_, err = repository.NewCountryRepository(query).Create(context.Background(), model.Country{Code: "NZ"})
+	require.NoError(t, err)
+	_, err = repository.NewCountryRepository(query).Create(context.Background(), model.Country{Code: "AU"})
+	require.NoError(t, err)
+
+	repo := repository.NewMembershipRepository(query)
+	controller := api.NewMembershipController(query)
+
+	for _, code := range []string{"NZ", "AU"} {
+		response, err := controller.PostMembership(context.Background(), api.PostMembershipRequestObject{
+			Body: &api.CreateMembership{
+				AccountID:   account.ID,
+				CountryCode: code,
+				Role:        "member",
+			},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		err = response.VisitPostMembershipResponse(rec)
+		require.NoError(t, err)
+		require.Equal(t, 201, rec.Code)
+	}
+
+	// Act
+	patchResponse, err := controller.PatchMembershipID(context.Background(), api.PatchMembershipIDRequestObject{
+		AccountID:   account.ID,
+		CountryCode: "NZ",
+		Body:        &api.PatchMembershipIDApplicationMergePatchPlusJSONRequestBody{"role": "owner"},
+	})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	err = patchResponse.VisitPatchMembershipIDResponse(rec)
+	require.NoError(t, err)
+	require.Equal(t, 204, rec.Code)
+
+	getResponse, err := controller.GetMembershipID(context.Background(), api.GetMembershipIDRequestObject{
+		AccountID:   account.ID,
+		CountryCode: "NZ",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec = httptest.NewRecorder()
+	err = getResponse.VisitGetMembershipIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	actual := &api.Membership{}
+	err = json.Unmarshal(rec.Body.Bytes(), actual)
+	require.NoError(t, err)
+	assert.Equal(t, account.ID, actual.AccountID)
+	assert.Equal(t, "NZ", actual.CountryCode)
+	assert.Equal(t, "owner", actual.Role)
+
+	// Only the membership matching both keys was patched
+	other, err := repo.Get(context.Background(), account.ID, "AU")
+	require.NoError(t, err)
+	assert.Equal(t, "member", other.Role)
+}
+
+func Test_GetTicket_CursorByKey(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewTicketRepository(query)
+	controller := api.NewTicketController(query)
+
+	tickets := []model.Ticket{}
+	for _, title := range []string{"First", "Second", "Third"} {
+		ticket, err := repo.Create(context.Background(), model.Ticket{Title: title})
+		require.NoError(t, err)
+		tickets = append(tickets, *ticket)
+	}
+
+	// Act
+	cursor := tickets[0].Number
+	limit := 1
+	response, err := controller.GetTicket(context.Background(), api.GetTicketRequestObject{
+		Params: api.GetTicketParams{Cursor: &cursor, Limit: &limit},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetTicketResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, fmt.Sprintf(`<?cursor=%v&limit=1>; rel="next"`, tickets[1].Number), rec.Header().Get("Link"))
+
+	actual := []api.Ticket{}
+	err = json.Unmarshal(rec.Body.Bytes(), &actual)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "Second", actual[0].Title)
+}
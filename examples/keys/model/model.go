@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// An account, identified by a UUID
+type Account struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name string
+}
+
+func (a *Account) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// A country, identified by its ISO 3166 code
+type Country struct {
+	Code string `gorm:"primaryKey;size:2"`
+	Name string
+}
+
+// An account's membership in a country, identified by both
+type Membership struct {
+	AccountID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CountryCode string    `gorm:"primaryKey;size:2"`
+	Role        string
+}
+
+// A support ticket, identified by an integer that isn't named ID
+type Ticket struct {
+	Number int64 `gorm:"primaryKey"`
+	Title  string
+}
@@ -0,0 +1,111 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+
+	"gorm.io/gen"
+
+	"gorm.io/plugin/dbresolver"
+)
+
+func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
+	return &Query{
+		db:         db,
+		Account:    newAccount(db, opts...),
+		Country:    newCountry(db, opts...),
+		Membership: newMembership(db, opts...),
+		Ticket:     newTicket(db, opts...),
+	}
+}
+
+type Query struct {
+	db *gorm.DB
+
+	Account    account
+	Country    country
+	Membership membership
+	Ticket     ticket
+}
+
+func (q *Query) Available() bool { return q.db != nil }
+
+func (q *Query) clone(db *gorm.DB) *Query {
+	return &Query{
+		db:         db,
+		Account:    q.Account.clone(db),
+		Country:    q.Country.clone(db),
+		Membership: q.Membership.clone(db),
+		Ticket:     q.Ticket.clone(db),
+	}
+}
+
+func (q *Query) ReadDB() *Query {
+	return q.ReplaceDB(q.db.Clauses(dbresolver.Read))
+}
+
+func (q *Query) WriteDB() *Query {
+	return q.ReplaceDB(q.db.Clauses(dbresolver.Write))
+}
+
+func (q *Query) ReplaceDB(db *gorm.DB) *Query {
+	return &Query{
+		db:         db,
+		Account:    q.Account.replaceDB(db),
+		Country:    q.Country.replaceDB(db),
+		Membership: q.Membership.replaceDB(db),
+		Ticket:     q.Ticket.replaceDB(db),
+	}
+}
+
+type queryCtx struct {
+	Account    IAccountDo
+	Country    ICountryDo
+	Membership IMembershipDo
+	Ticket     ITicketDo
+}
+
+func (q *Query) WithContext(ctx context.Context) *queryCtx {
+	return &queryCtx{
+		Account:    q.Account.WithContext(ctx),
+		Country:    q.Country.WithContext(ctx),
+		Membership: q.Membership.WithContext(ctx),
+		Ticket:     q.Ticket.WithContext(ctx),
+	}
+}
+
+func (q *Query) Transaction(fc func(tx *Query) error, opts ...*sql.TxOptions) error {
+	return q.db.Transaction(func(tx *gorm.DB) error { return fc(q.clone(tx)) }, opts...)
+}
+
+func (q *Query) Begin(opts ...*sql.TxOptions) *QueryTx {
+	tx := q.db.Begin(opts...)
+	return &QueryTx{Query: q.clone(tx), Error: tx.Error}
+}
+
+type QueryTx struct {
+	*Query
+	Error error
+}
+
+func (q *QueryTx) Commit() error {
+	return q.db.Commit().Error
+}
+
+func (q *QueryTx) Rollback() error {
+	return q.db.Rollback().Error
+}
+
+func (q *QueryTx) SavePoint(name string) error {
+	return q.db.SavePoint(name).Error
+}
+
+func (q *QueryTx) RollbackTo(name string) error {
+	return q.db.RollbackTo(name).Error
+}
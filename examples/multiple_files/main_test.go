@@ -19,7 +19,7 @@ import (
 func newQuery(t *testing.T) *query.Query {
 	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(model.User{}))
+	require.NoError(t, db.AutoMigrate(model.User{}, model.Bio{}))
 	query := query.Use(db)
 	return query
 }
@@ -169,3 +169,104 @@ func Test_DeleteUserID(t *testing.T) {
 	_, err = repo.Get(context.Background(), int64(user.ID))
 	require.Error(t, err, "User was not deleted from database")
 }
+
+func Test_GetUserID_HiddenField(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewUserRepository(query)
+	user, err := repo.Create(context.Background(), model.User{Name: "Bob", PasswordHash: "secret"})
+	require.NoError(t, err)
+
+	controller := api.NewUserController(query)
+
+	// Act
+	response, err := controller.GetUserID(context.Background(), api.GetUserIDRequestObject{
+		ID: int64(user.ID),
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetUserIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	actual := map[string]any{}
+	err = json.Unmarshal(rec.Body.Bytes(), &actual)
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", actual["name"])
+	assert.Contains(t, actual, "createdAt")
+	assert.NotContains(t, actual, "passwordHash")
+	assert.NotContains(t, actual, "PasswordHash")
+	assert.NotContains(t, rec.Body.String(), "secret")
+}
+
+func Test_PutUserID_KeepsHiddenField(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	repo := repository.NewUserRepository(query)
+	user, err := repo.Create(context.Background(), model.User{Name: "Bob", PasswordHash: "secret"})
+	require.NoError(t, err)
+
+	controller := api.NewUserController(query)
+
+	// Act
+	response, err := controller.PutUserID(context.Background(), api.PutUserIDRequestObject{
+		ID: int64(user.ID),
+		Body: &api.UpdateUser{
+			Name: "Jim",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPutUserIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 204, rec.Code)
+
+	user, err = repo.Get(context.Background(), int64(user.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Jim", user.Name)
+	assert.Equal(t, "secret", user.PasswordHash)
+}
+
+func Test_GetBio_RenamedField(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	userRepo := repository.NewUserRepository(query)
+	user, err := userRepo.Create(context.Background(), model.User{Name: "Bob"})
+	require.NoError(t, err)
+
+	repo := repository.NewBioRepository(query)
+	_, err = repo.Create(context.Background(), model.Bio{Description: "Hello", UserID: &user.ID})
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), model.Bio{Description: "Goodbye", UserID: &user.ID})
+	require.NoError(t, err)
+
+	controller := api.NewBioController(query)
+
+	// Act
+	about := "Hello"
+	response, err := controller.GetBio(context.Background(), api.GetBioRequestObject{
+		Params: api.GetBioParams{
+			About: &about,
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetBioResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	bios := []map[string]any{}
+	err = json.Unmarshal(rec.Body.Bytes(), &bios)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(bios))
+	assert.Equal(t, "Hello", bios[0]["about"])
+	assert.Equal(t, float64(user.ID), bios[0]["userId"])
+	assert.NotContains(t, bios[0], "description")
+	assert.NotContains(t, bios[0], "website")
+}
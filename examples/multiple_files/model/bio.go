@@ -4,7 +4,8 @@ import "gorm.io/gorm"
 
 type Bio struct {
 	gorm.Model
-	Description string
+	Description string `json:"about"`
+	Website     string `json:"website,omitempty"`
 	UserID      *uint
 	User        User
 }
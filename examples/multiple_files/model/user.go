@@ -4,5 +4,6 @@ import "gorm.io/gorm"
 
 type User struct {
 	gorm.Model
-	Name string `gorm:"column:name;"`
+	Name         string `gorm:"column:name;"`
+	PasswordHash string `json:"-"`
 }
@@ -32,6 +32,7 @@ func newBio(db *gorm.DB, opts ...gen.DOOption) bio {
 	_bio.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_bio.DeletedAt = field.NewField(tableName, "deleted_at")
 	_bio.Description = field.NewString(tableName, "description")
+	_bio.Website = field.NewString(tableName, "website")
 	_bio.UserID = field.NewUint(tableName, "user_id")
 	_bio.User = bioBelongsToUser{
 		db: db.Session(&gorm.Session{}),
@@ -45,7 +46,7 @@ func newBio(db *gorm.DB, opts ...gen.DOOption) bio {
 }
 
 type bio struct {
-	bioDo
+	bioDo bioDo
 
 	ALL         field.Asterisk
 	ID          field.Uint
@@ -53,6 +54,7 @@ type bio struct {
 	UpdatedAt   field.Time
 	DeletedAt   field.Field
 	Description field.String
+	Website     field.String
 	UserID      field.Uint
 	User        bioBelongsToUser
 
@@ -76,6 +78,7 @@ func (b *bio) updateTableName(table string) *bio {
 	b.UpdatedAt = field.NewTime(table, "updated_at")
 	b.DeletedAt = field.NewField(table, "deleted_at")
 	b.Description = field.NewString(table, "description")
+	b.Website = field.NewString(table, "website")
 	b.UserID = field.NewUint(table, "user_id")
 
 	b.fillFieldMap()
@@ -83,6 +86,14 @@ func (b *bio) updateTableName(table string) *bio {
 	return b
 }
 
+func (b *bio) WithContext(ctx context.Context) IBioDo { return b.bioDo.WithContext(ctx) }
+
+func (b bio) TableName() string { return b.bioDo.TableName() }
+
+func (b bio) Alias() string { return b.bioDo.Alias() }
+
+func (b bio) Columns(cols ...field.Expr) gen.Columns { return b.bioDo.Columns(cols...) }
+
 func (b *bio) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := b.fieldMap[fieldName]
 	if !ok || _f == nil {
@@ -93,12 +104,13 @@ func (b *bio) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (b *bio) fillFieldMap() {
-	b.fieldMap = make(map[string]field.Expr, 7)
+	b.fieldMap = make(map[string]field.Expr, 8)
 	b.fieldMap["id"] = b.ID
 	b.fieldMap["created_at"] = b.CreatedAt
 	b.fieldMap["updated_at"] = b.UpdatedAt
 	b.fieldMap["deleted_at"] = b.DeletedAt
 	b.fieldMap["description"] = b.Description
+	b.fieldMap["website"] = b.Website
 	b.fieldMap["user_id"] = b.UserID
 
 }
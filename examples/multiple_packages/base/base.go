@@ -0,0 +1,9 @@
+package base
+
+import "gorm.io/gorm"
+
+// The base of every model
+type Base struct {
+	gorm.Model
+	CreatedBy string
+}
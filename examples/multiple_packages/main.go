@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet/billing"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/identity"
+	"gorm.io/gen"
+)
+
+func main() {
+	g := gen.NewGenerator(gen.Config{
+		OutPath: "query",
+		Mode:    gen.WithQueryInterface,
+	})
+	g.ApplyBasic(
+		identity.Person{},
+		fleet.Vehicle{},
+		billing.Invoice{},
+	)
+	g.Execute()
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeriddles/goalesce/examples/multiple_packages/generated/api"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/generated/repository"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet/billing"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/identity"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newQuery(t *testing.T) *query.Query {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(identity.Person{}, fleet.Vehicle{}, billing.Invoice{}))
+	query := query.Use(db)
+	return query
+}
+
+func Test_PostInvoice(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	person, err := repository.NewPersonRepository(query).Create(context.Background(), identity.Person{Name: "Bob"})
+	require.NoError(t, err)
+	vehicle, err := repository.NewVehicleRepository(query).Create(context.Background(), fleet.Vehicle{Vin: "1HGCM82633A004352", OwnerID: person.ID})
+	require.NoError(t, err)
+
+	repo := repository.NewInvoiceRepository(query)
+	controller := api.NewInvoiceController(query)
+
+	// Act
+	response, err := controller.PostInvoice(context.Background(), api.PostInvoiceRequestObject{
+		Body: &api.CreateInvoice{
+			Amount:    100,
+			VehicleID: int(vehicle.ID),
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitPostInvoiceResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 201, rec.Code)
+
+	invoice := &api.Invoice{}
+	err = json.Unmarshal(rec.Body.Bytes(), invoice)
+	require.NoError(t, err)
+	assert.Equal(t, 100, invoice.Amount)
+	require.NotEqual(t, 0, invoice.ID)
+
+	invoiceFromDb, err := repo.Get(context.Background(), int64(invoice.ID))
+	require.NoError(t, err)
+	assert.Equal(t, vehicle.ID, invoiceFromDb.VehicleID)
+}
+
+func Test_GetVehicleID(t *testing.T) {
+	// Arrange
+	query := newQuery(t)
+	person, err := repository.NewPersonRepository(query).Create(context.Background(), identity.Person{Name: "Bob"})
+	require.NoError(t, err)
+	vehicle, err := repository.NewVehicleRepository(query).Create(context.Background(), fleet.Vehicle{Vin: "1HGCM82633A004352", OwnerID: person.ID})
+	require.NoError(t, err)
+
+	controller := api.NewVehicleController(query)
+
+	// Act
+	response, err := controller.GetVehicleID(context.Background(), api.GetVehicleIDRequestObject{ID: int64(vehicle.ID)})
+	require.NoError(t, err)
+
+	// Assert
+	rec := httptest.NewRecorder()
+	err = response.VisitGetVehicleIDResponse(rec)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+
+	apiVehicle := &api.Vehicle{}
+	err = json.Unmarshal(rec.Body.Bytes(), apiVehicle)
+	require.NoError(t, err)
+	assert.Equal(t, "1HGCM82633A004352", apiVehicle.Vin)
+	assert.Equal(t, int(person.ID), apiVehicle.OwnerID)
+}
@@ -0,0 +1,14 @@
+package billing
+
+import (
+	"github.com/joeriddles/goalesce/examples/multiple_packages/base"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet"
+)
+
+// An invoice for servicing a vehicle
+type Invoice struct {
+	base.Base
+	Amount    int
+	VehicleID uint
+	Vehicle   fleet.Vehicle
+}
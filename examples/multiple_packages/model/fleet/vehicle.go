@@ -0,0 +1,14 @@
+package fleet
+
+import (
+	"github.com/joeriddles/goalesce/examples/multiple_packages/base"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/identity"
+)
+
+// A vehicle, owned by a person
+type Vehicle struct {
+	base.Base
+	Vin     string
+	OwnerID uint
+	Owner   identity.Person
+}
@@ -0,0 +1,9 @@
+package identity
+
+import "github.com/joeriddles/goalesce/examples/multiple_packages/base"
+
+// A person, who may own a vehicle
+type Person struct {
+	base.Base
+	Name string
+}
@@ -0,0 +1,491 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet"
+	"github.com/joeriddles/goalesce/examples/multiple_packages/model/identity"
+)
+
+func newVehicle(db *gorm.DB, opts ...gen.DOOption) vehicle {
+	_vehicle := vehicle{}
+
+	_vehicle.vehicleDo.UseDB(db, opts...)
+	_vehicle.vehicleDo.UseModel(&fleet.Vehicle{})
+
+	tableName := _vehicle.vehicleDo.TableName()
+	_vehicle.ALL = field.NewAsterisk(tableName)
+	_vehicle.ID = field.NewUint(tableName, "id")
+	_vehicle.CreatedAt = field.NewTime(tableName, "created_at")
+	_vehicle.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_vehicle.DeletedAt = field.NewField(tableName, "deleted_at")
+	_vehicle.CreatedBy = field.NewString(tableName, "created_by")
+	_vehicle.Vin = field.NewString(tableName, "vin")
+	_vehicle.OwnerID = field.NewUint(tableName, "owner_id")
+	_vehicle.Owner = vehicleBelongsToOwner{
+		db: db.Session(&gorm.Session{}),
+
+		RelationField: field.NewRelation("Owner", "identity.Person"),
+	}
+
+	_vehicle.fillFieldMap()
+
+	return _vehicle
+}
+
+type vehicle struct {
+	vehicleDo vehicleDo
+
+	ALL       field.Asterisk
+	ID        field.Uint
+	CreatedAt field.Time
+	UpdatedAt field.Time
+	DeletedAt field.Field
+	CreatedBy field.String
+	Vin       field.String
+	OwnerID   field.Uint
+	Owner     vehicleBelongsToOwner
+
+	fieldMap map[string]field.Expr
+}
+
+func (v vehicle) Table(newTableName string) *vehicle {
+	v.vehicleDo.UseTable(newTableName)
+	return v.updateTableName(newTableName)
+}
+
+func (v vehicle) As(alias string) *vehicle {
+	v.vehicleDo.DO = *(v.vehicleDo.As(alias).(*gen.DO))
+	return v.updateTableName(alias)
+}
+
+func (v *vehicle) updateTableName(table string) *vehicle {
+	v.ALL = field.NewAsterisk(table)
+	v.ID = field.NewUint(table, "id")
+	v.CreatedAt = field.NewTime(table, "created_at")
+	v.UpdatedAt = field.NewTime(table, "updated_at")
+	v.DeletedAt = field.NewField(table, "deleted_at")
+	v.CreatedBy = field.NewString(table, "created_by")
+	v.Vin = field.NewString(table, "vin")
+	v.OwnerID = field.NewUint(table, "owner_id")
+
+	v.fillFieldMap()
+
+	return v
+}
+
+func (v *vehicle) WithContext(ctx context.Context) IVehicleDo { return v.vehicleDo.WithContext(ctx) }
+
+func (v vehicle) TableName() string { return v.vehicleDo.TableName() }
+
+func (v vehicle) Alias() string { return v.vehicleDo.Alias() }
+
+func (v vehicle) Columns(cols ...field.Expr) gen.Columns { return v.vehicleDo.Columns(cols...) }
+
+func (v *vehicle) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := v.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (v *vehicle) fillFieldMap() {
+	v.fieldMap = make(map[string]field.Expr, 8)
+	v.fieldMap["id"] = v.ID
+	v.fieldMap["created_at"] = v.CreatedAt
+	v.fieldMap["updated_at"] = v.UpdatedAt
+	v.fieldMap["deleted_at"] = v.DeletedAt
+	v.fieldMap["created_by"] = v.CreatedBy
+	v.fieldMap["vin"] = v.Vin
+	v.fieldMap["owner_id"] = v.OwnerID
+
+}
+
+func (v vehicle) clone(db *gorm.DB) vehicle {
+	v.vehicleDo.ReplaceConnPool(db.Statement.ConnPool)
+	return v
+}
+
+func (v vehicle) replaceDB(db *gorm.DB) vehicle {
+	v.vehicleDo.ReplaceDB(db)
+	return v
+}
+
+type vehicleBelongsToOwner struct {
+	db *gorm.DB
+
+	field.RelationField
+}
+
+func (a vehicleBelongsToOwner) Where(conds ...field.Expr) *vehicleBelongsToOwner {
+	if len(conds) == 0 {
+		return &a
+	}
+
+	exprs := make([]clause.Expression, 0, len(conds))
+	for _, cond := range conds {
+		exprs = append(exprs, cond.BeCond().(clause.Expression))
+	}
+	a.db = a.db.Clauses(clause.Where{Exprs: exprs})
+	return &a
+}
+
+func (a vehicleBelongsToOwner) WithContext(ctx context.Context) *vehicleBelongsToOwner {
+	a.db = a.db.WithContext(ctx)
+	return &a
+}
+
+func (a vehicleBelongsToOwner) Session(session *gorm.Session) *vehicleBelongsToOwner {
+	a.db = a.db.Session(session)
+	return &a
+}
+
+func (a vehicleBelongsToOwner) Model(m *fleet.Vehicle) *vehicleBelongsToOwnerTx {
+	return &vehicleBelongsToOwnerTx{a.db.Model(m).Association(a.Name())}
+}
+
+type vehicleBelongsToOwnerTx struct{ tx *gorm.Association }
+
+func (a vehicleBelongsToOwnerTx) Find() (result *identity.Person, err error) {
+	return result, a.tx.Find(&result)
+}
+
+func (a vehicleBelongsToOwnerTx) Append(values ...*identity.Person) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Append(targetValues...)
+}
+
+func (a vehicleBelongsToOwnerTx) Replace(values ...*identity.Person) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Replace(targetValues...)
+}
+
+func (a vehicleBelongsToOwnerTx) Delete(values ...*identity.Person) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Delete(targetValues...)
+}
+
+func (a vehicleBelongsToOwnerTx) Clear() error {
+	return a.tx.Clear()
+}
+
+func (a vehicleBelongsToOwnerTx) Count() int64 {
+	return a.tx.Count()
+}
+
+type vehicleDo struct{ gen.DO }
+
+type IVehicleDo interface {
+	gen.SubQuery
+	Debug() IVehicleDo
+	WithContext(ctx context.Context) IVehicleDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IVehicleDo
+	WriteDB() IVehicleDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IVehicleDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IVehicleDo
+	Not(conds ...gen.Condition) IVehicleDo
+	Or(conds ...gen.Condition) IVehicleDo
+	Select(conds ...field.Expr) IVehicleDo
+	Where(conds ...gen.Condition) IVehicleDo
+	Order(conds ...field.Expr) IVehicleDo
+	Distinct(cols ...field.Expr) IVehicleDo
+	Omit(cols ...field.Expr) IVehicleDo
+	Join(table schema.Tabler, on ...field.Expr) IVehicleDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IVehicleDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IVehicleDo
+	Group(cols ...field.Expr) IVehicleDo
+	Having(conds ...gen.Condition) IVehicleDo
+	Limit(limit int) IVehicleDo
+	Offset(offset int) IVehicleDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IVehicleDo
+	Unscoped() IVehicleDo
+	Create(values ...*fleet.Vehicle) error
+	CreateInBatches(values []*fleet.Vehicle, batchSize int) error
+	Save(values ...*fleet.Vehicle) error
+	First() (*fleet.Vehicle, error)
+	Take() (*fleet.Vehicle, error)
+	Last() (*fleet.Vehicle, error)
+	Find() ([]*fleet.Vehicle, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*fleet.Vehicle, err error)
+	FindInBatches(result *[]*fleet.Vehicle, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*fleet.Vehicle) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IVehicleDo
+	Assign(attrs ...field.AssignExpr) IVehicleDo
+	Joins(fields ...field.RelationField) IVehicleDo
+	Preload(fields ...field.RelationField) IVehicleDo
+	FirstOrInit() (*fleet.Vehicle, error)
+	FirstOrCreate() (*fleet.Vehicle, error)
+	FindByPage(offset int, limit int) (result []*fleet.Vehicle, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IVehicleDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (v vehicleDo) Debug() IVehicleDo {
+	return v.withDO(v.DO.Debug())
+}
+
+func (v vehicleDo) WithContext(ctx context.Context) IVehicleDo {
+	return v.withDO(v.DO.WithContext(ctx))
+}
+
+func (v vehicleDo) ReadDB() IVehicleDo {
+	return v.Clauses(dbresolver.Read)
+}
+
+func (v vehicleDo) WriteDB() IVehicleDo {
+	return v.Clauses(dbresolver.Write)
+}
+
+func (v vehicleDo) Session(config *gorm.Session) IVehicleDo {
+	return v.withDO(v.DO.Session(config))
+}
+
+func (v vehicleDo) Clauses(conds ...clause.Expression) IVehicleDo {
+	return v.withDO(v.DO.Clauses(conds...))
+}
+
+func (v vehicleDo) Returning(value interface{}, columns ...string) IVehicleDo {
+	return v.withDO(v.DO.Returning(value, columns...))
+}
+
+func (v vehicleDo) Not(conds ...gen.Condition) IVehicleDo {
+	return v.withDO(v.DO.Not(conds...))
+}
+
+func (v vehicleDo) Or(conds ...gen.Condition) IVehicleDo {
+	return v.withDO(v.DO.Or(conds...))
+}
+
+func (v vehicleDo) Select(conds ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Select(conds...))
+}
+
+func (v vehicleDo) Where(conds ...gen.Condition) IVehicleDo {
+	return v.withDO(v.DO.Where(conds...))
+}
+
+func (v vehicleDo) Order(conds ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Order(conds...))
+}
+
+func (v vehicleDo) Distinct(cols ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Distinct(cols...))
+}
+
+func (v vehicleDo) Omit(cols ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Omit(cols...))
+}
+
+func (v vehicleDo) Join(table schema.Tabler, on ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Join(table, on...))
+}
+
+func (v vehicleDo) LeftJoin(table schema.Tabler, on ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.LeftJoin(table, on...))
+}
+
+func (v vehicleDo) RightJoin(table schema.Tabler, on ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.RightJoin(table, on...))
+}
+
+func (v vehicleDo) Group(cols ...field.Expr) IVehicleDo {
+	return v.withDO(v.DO.Group(cols...))
+}
+
+func (v vehicleDo) Having(conds ...gen.Condition) IVehicleDo {
+	return v.withDO(v.DO.Having(conds...))
+}
+
+func (v vehicleDo) Limit(limit int) IVehicleDo {
+	return v.withDO(v.DO.Limit(limit))
+}
+
+func (v vehicleDo) Offset(offset int) IVehicleDo {
+	return v.withDO(v.DO.Offset(offset))
+}
+
+func (v vehicleDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IVehicleDo {
+	return v.withDO(v.DO.Scopes(funcs...))
+}
+
+func (v vehicleDo) Unscoped() IVehicleDo {
+	return v.withDO(v.DO.Unscoped())
+}
+
+func (v vehicleDo) Create(values ...*fleet.Vehicle) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return v.DO.Create(values)
+}
+
+func (v vehicleDo) CreateInBatches(values []*fleet.Vehicle, batchSize int) error {
+	return v.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (v vehicleDo) Save(values ...*fleet.Vehicle) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return v.DO.Save(values)
+}
+
+func (v vehicleDo) First() (*fleet.Vehicle, error) {
+	if result, err := v.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*fleet.Vehicle), nil
+	}
+}
+
+func (v vehicleDo) Take() (*fleet.Vehicle, error) {
+	if result, err := v.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*fleet.Vehicle), nil
+	}
+}
+
+func (v vehicleDo) Last() (*fleet.Vehicle, error) {
+	if result, err := v.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*fleet.Vehicle), nil
+	}
+}
+
+func (v vehicleDo) Find() ([]*fleet.Vehicle, error) {
+	result, err := v.DO.Find()
+	return result.([]*fleet.Vehicle), err
+}
+
+func (v vehicleDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*fleet.Vehicle, err error) {
+	buf := make([]*fleet.Vehicle, 0, batchSize)
+	err = v.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (v vehicleDo) FindInBatches(result *[]*fleet.Vehicle, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return v.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (v vehicleDo) Attrs(attrs ...field.AssignExpr) IVehicleDo {
+	return v.withDO(v.DO.Attrs(attrs...))
+}
+
+func (v vehicleDo) Assign(attrs ...field.AssignExpr) IVehicleDo {
+	return v.withDO(v.DO.Assign(attrs...))
+}
+
+func (v vehicleDo) Joins(fields ...field.RelationField) IVehicleDo {
+	for _, _f := range fields {
+		v = *v.withDO(v.DO.Joins(_f))
+	}
+	return &v
+}
+
+func (v vehicleDo) Preload(fields ...field.RelationField) IVehicleDo {
+	for _, _f := range fields {
+		v = *v.withDO(v.DO.Preload(_f))
+	}
+	return &v
+}
+
+func (v vehicleDo) FirstOrInit() (*fleet.Vehicle, error) {
+	if result, err := v.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*fleet.Vehicle), nil
+	}
+}
+
+func (v vehicleDo) FirstOrCreate() (*fleet.Vehicle, error) {
+	if result, err := v.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*fleet.Vehicle), nil
+	}
+}
+
+func (v vehicleDo) FindByPage(offset int, limit int) (result []*fleet.Vehicle, count int64, err error) {
+	result, err = v.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = v.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (v vehicleDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = v.Count()
+	if err != nil {
+		return
+	}
+
+	err = v.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (v vehicleDo) Scan(result interface{}) (err error) {
+	return v.DO.Scan(result)
+}
+
+func (v vehicleDo) Delete(models ...*fleet.Vehicle) (result gen.ResultInfo, err error) {
+	return v.DO.Delete(models)
+}
+
+func (v *vehicleDo) withDO(do gen.Dao) *vehicleDo {
+	v.DO = *do.(*gen.DO)
+	return v
+}
@@ -72,7 +72,7 @@ func newPointer(db *gorm.DB, opts ...gen.DOOption) pointer {
 }
 
 type pointer struct {
-	pointerDo
+	pointerDo pointerDo
 
 	ALL       field.Asterisk
 	ID        field.Int
@@ -114,6 +114,14 @@ func (p *pointer) updateTableName(table string) *pointer {
 	return p
 }
 
+func (p *pointer) WithContext(ctx context.Context) IPointerDo { return p.pointerDo.WithContext(ctx) }
+
+func (p pointer) TableName() string { return p.pointerDo.TableName() }
+
+func (p pointer) Alias() string { return p.pointerDo.Alias() }
+
+func (p pointer) Columns(cols ...field.Expr) gen.Columns { return p.pointerDo.Columns(cols...) }
+
 func (p *pointer) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := p.fieldMap[fieldName]
 	if !ok || _f == nil {
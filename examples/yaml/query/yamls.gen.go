@@ -39,7 +39,7 @@ func newYaml(db *gorm.DB, opts ...gen.DOOption) yaml {
 }
 
 type yaml struct {
-	yamlDo
+	yamlDo yamlDo
 
 	ALL       field.Asterisk
 	ID        field.Uint
@@ -74,6 +74,14 @@ func (y *yaml) updateTableName(table string) *yaml {
 	return y
 }
 
+func (y *yaml) WithContext(ctx context.Context) IYamlDo { return y.yamlDo.WithContext(ctx) }
+
+func (y yaml) TableName() string { return y.yamlDo.TableName() }
+
+func (y yaml) Alias() string { return y.yamlDo.Alias() }
+
+func (y yaml) Columns(cols ...field.Expr) gen.Columns { return y.yamlDo.Columns(cols...) }
+
 func (y *yaml) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 	_f, ok := y.fieldMap[fieldName]
 	if !ok || _f == nil {
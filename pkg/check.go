@@ -0,0 +1,292 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joeriddles/goalesce/pkg/config"
+	"github.com/joeriddles/goalesce/pkg/generate"
+	"github.com/joeriddles/goalesce/pkg/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/mod/modfile"
+)
+
+// Check if the generated code is up to date, without modifying the output.
+//
+// The code is generated into a scratch copy of the module, since the generated
+// import paths depend on where the output is in the module. A unified diff of
+// every stale file is written to w.
+func Check(cfg *config.Config, w io.Writer) (bool, error) {
+	modulePath, err := utils.FindGoMod(cfg.OutputFile, cfg.ModuleName)
+	if err != nil {
+		return false, err
+	}
+	moduleRoot := filepath.Dir(modulePath)
+
+	scratchRoot, err := os.MkdirTemp("", "goalesce-check-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(scratchRoot)
+
+	// The outputs aren't copied, so files that are no longer generated are
+	// missing from the scratch copy
+	if err := copyModule(moduleRoot, scratchRoot, outputPaths(cfg)...); err != nil {
+		return false, err
+	}
+
+	scratchCfg := rebaseConfig(cfg, moduleRoot, scratchRoot)
+	if err := Run(scratchCfg); err != nil {
+		return false, err
+	}
+
+	// Files from the last run that are missing from the scratch output are
+	// stale. Without a manifest, the whole output directory is cleared.
+	generated, err := generate.GeneratedFiles(cfg.OutputFile)
+	if err != nil {
+		return false, err
+	}
+
+	upToDate := true
+	outputs := []struct {
+		path string
+		// If true, files missing from the scratch output are stale
+		clear bool
+	}{
+		{cfg.OutputFile, cfg.ClearOutputDir && generated == nil},
+		{cfg.RepositoryConfiguration.OutputFile, false},
+		{cfg.TypesCodegen.OutputFile, false},
+		{cfg.ServerCodegen.OutputFile, false},
+	}
+	checked := map[string]bool{}
+	for _, output := range outputs {
+		rel, err := filepath.Rel(moduleRoot, output.path)
+		if err != nil {
+			return false, err
+		}
+		stale, err := diffTree(w, moduleRoot, scratchRoot, rel, output.clear, checked)
+		if err != nil {
+			return false, err
+		}
+		if stale {
+			upToDate = false
+		}
+	}
+
+	for _, fp := range generated {
+		file, err := filepath.Rel(moduleRoot, fp)
+		if err != nil {
+			return false, err
+		}
+		if checked[file] {
+			continue
+		}
+		checked[file] = true
+		stale, err := diffFile(w, moduleRoot, scratchRoot, file)
+		if err != nil {
+			return false, err
+		}
+		if stale {
+			upToDate = false
+		}
+	}
+
+	return upToDate, nil
+}
+
+// Get the paths of the output directory and the outputs that can be outside
+// of it. Outputs that don't have defaults yet, before validation, are left out.
+func outputPaths(cfg *config.Config) []string {
+	paths := []string{cfg.OutputFile}
+	if cfg.RepositoryConfiguration != nil {
+		paths = append(paths, cfg.RepositoryConfiguration.OutputFile)
+	}
+	if cfg.TypesCodegen != nil {
+		paths = append(paths, cfg.TypesCodegen.OutputFile)
+	}
+	if cfg.ServerCodegen != nil {
+		paths = append(paths, cfg.ServerCodegen.OutputFile)
+	}
+	return paths
+}
+
+// Copy the module's files into dst, skipping .git and the skipped files and directories
+func copyModule(src string, dst string, skip ...string) error {
+	skipped := map[string]bool{}
+	for _, path := range skip {
+		skipped[filepath.Clean(path)] = true
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			if d.Name() == ".git" || skipped[path] {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, os.ModePerm)
+		}
+		if !d.Type().IsRegular() || skipped[path] {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if rel == "go.mod" {
+			data, err = absoluteReplaces(path, data)
+			if err != nil {
+				return err
+			}
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
+
+// Make relative replace directives absolute so they still resolve from the scratch copy
+func absoluteReplaces(fp string, data []byte) ([]byte, error) {
+	f, err := modfile.Parse(fp, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, replace := range f.Replace {
+		if modfile.IsDirectoryPath(replace.New.Path) && !filepath.IsAbs(replace.New.Path) {
+			path := filepath.Join(filepath.Dir(fp), replace.New.Path)
+			if err := f.AddReplace(replace.Old.Path, replace.Old.Version, path, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.Format()
+}
+
+// Copy the config, moving any paths in the module to the scratch copy
+func rebaseConfig(cfg *config.Config, moduleRoot string, scratchRoot string) *config.Config {
+	rebase := func(path string) string {
+		rel, err := filepath.Rel(moduleRoot, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return path
+		}
+		return filepath.Join(scratchRoot, rel)
+	}
+
+	scratchCfg := *cfg
+	scratchCfg.InputFolderPaths = utils.Map(cfg.InputFolderPaths, rebase)
+	scratchCfg.OutputFile = rebase(cfg.OutputFile)
+	if cfg.OpenApiFile != "" {
+		scratchCfg.OpenApiFile = rebase(cfg.OpenApiFile)
+	}
+
+	repositoryCfg := *cfg.RepositoryConfiguration
+	repositoryCfg.OutputFile = rebase(cfg.RepositoryConfiguration.OutputFile)
+	scratchCfg.RepositoryConfiguration = &repositoryCfg
+
+	typesCodegen := *cfg.TypesCodegen
+	typesCodegen.OutputFile = rebase(cfg.TypesCodegen.OutputFile)
+	scratchCfg.TypesCodegen = &typesCodegen
+
+	serverCodegen := *cfg.ServerCodegen
+	serverCodegen.OutputFile = rebase(cfg.ServerCodegen.OutputFile)
+	scratchCfg.ServerCodegen = &serverCodegen
+
+	return &scratchCfg
+}
+
+// Write a unified diff of each file under rel that differs between the module and the scratch copy
+func diffTree(w io.Writer, moduleRoot string, scratchRoot string, rel string, clear bool, checked map[string]bool) (bool, error) {
+	files := []string{}
+	for _, root := range []string{scratchRoot, moduleRoot} {
+		if root == moduleRoot && !clear {
+			break
+		}
+		err := filepath.WalkDir(filepath.Join(root, rel), func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				fileRel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				files = append(files, fileRel)
+			}
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	stale := false
+	for _, file := range files {
+		if checked[file] {
+			continue
+		}
+		checked[file] = true
+
+		fileStale, err := diffFile(w, moduleRoot, scratchRoot, file)
+		if err != nil {
+			return false, err
+		}
+		if fileStale {
+			stale = true
+		}
+	}
+	return stale, nil
+}
+
+// Write a unified diff of the file if it differs between the module and the
+// scratch copy. The version of goalesce in headers and the manifest is ignored,
+// so a different build of goalesce doesn't make the output stale.
+func diffFile(w io.Writer, moduleRoot string, scratchRoot string, file string) (bool, error) {
+	expected, err := readFileIfExists(filepath.Join(scratchRoot, file))
+	if err != nil {
+		return false, err
+	}
+	actual, err := readFileIfExists(filepath.Join(moduleRoot, file))
+	if err != nil {
+		return false, err
+	}
+	if string(generate.NormalizeVersion(file, []byte(expected))) == string(generate.NormalizeVersion(file, []byte(actual))) {
+		return false, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(actual),
+		B:        difflib.SplitLines(expected),
+		FromFile: filepath.ToSlash(filepath.Join("a", file)),
+		ToFile:   filepath.ToSlash(filepath.Join("b", file)),
+		Context:  3,
+	})
+	if err != nil {
+		return false, err
+	}
+	if _, err := fmt.Fprint(w, diff); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func readFileIfExists(fp string) (string, error) {
+	data, err := os.ReadFile(fp)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(data), err
+}
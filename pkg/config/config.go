@@ -3,25 +3,32 @@ package config
 import (
 	"errors"
 	"fmt"
+	"go/types"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"golang.org/x/tools/go/packages"
 	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	InputFolderPath string `yaml:"input_folder_path"`
+	// The folders or package patterns, like ./model/..., with the GORM models. May be a single path or a list.
+	InputFolderPaths StringList `yaml:"input_folder_path"`
 	// Where to output generated code, ./generated/ is default
 	OutputFile string `yaml:"output_file_path"`
 	// The name of the module the generated code will be part of
 	ModuleName string `yaml:"module_name"`
 	// The name of the package that the GORM models are part of
+	//
+	// Deprecated: each model's package is found when parsing the models.
 	ModelsPkg string `yaml:"models_package"`
 	// The name of the package that the GORM-generated Query is in
 	QueryPkg string `yaml:"query_package"`
-	// If true, clears the contents of the output directory before generating new files
+	// If true, clears the contents of the output directory before generating new files.
+	// If the output directory has a manifest from a previous run, only stale generated files are removed.
 	ClearOutputDir bool `yaml:"clear_output_dir"`
 	// If true, parses classes that do not inherit from gorm.Model
 	AllowCustomModels bool `yaml:"allow_custom_models"`
@@ -33,15 +40,25 @@ type Config struct {
 	ExcludeModels []string `yaml:"exclude_models,omitempty"`
 	// Exclude these field names from the generated create and read endpoints
 	ExcludeFields []string `yaml:"exclude_fields,omitempty"`
+	// How to name the API properties of fields without a json tag: snake_case, camelCase, or kebab-case. snake_case is default
+	NamingStrategy string `yaml:"naming_strategy,omitempty"`
 	// If true, generates a sample main.go file for running the server
 	GenerateMain bool `yaml:"generate_main"`
 	// If true, generates a server that uses all generated controllers
 	GenerateServer bool `yaml:"generate_server"`
+	// If true, formats generated Go code with the stricter gofumpt rules instead of gofmt
+	Gofumpt bool `yaml:"gofumpt"`
+	// How many associations deep the expand query parameter may preload, like vehicle_model.manufacturer. 2 is default
+	MaxExpandDepth int `yaml:"max_expand_depth,omitempty"`
+	// The maximum number of models to generate at once, the number of CPUs is default
+	Concurrency int `yaml:"concurrency,omitempty"`
 	// Override built-in templates from user-provided files
 	UserTemplates map[string]string `yaml:"user_templates,omitempty"`
 
 	// Generated Repository configuration
 	RepositoryConfiguration *RepositoryConfiguration `yaml:"repository,omitempty"`
+	// Generated list endpoint pagination configuration
+	PaginationConfiguration *PaginationConfiguration `yaml:"pagination,omitempty"`
 
 	// oapi-codegen server configuration
 	ServerCodegen *OApiGenConfiguration `yaml:"server_codegen,omitempty"`
@@ -49,6 +66,15 @@ type Config struct {
 	TypesCodegen *OApiGenConfiguration `yaml:"types_codegen,omitempty"`
 }
 
+// Naming strategies for API properties
+const (
+	SnakeCase = "snake_case"
+	CamelCase = "camelCase"
+	KebabCase = "kebab-case"
+)
+
+const DefaultMaxExpandDepth = 2
+
 type OApiGenConfiguration struct {
 	codegen.Configuration `yaml:",inline"`
 
@@ -77,8 +103,10 @@ func FromYamlFile(fp string) (*Config, error) {
 
 	// Make any relative paths relative to the YAML config filepath
 	configDir := filepath.Dir(absoluteConfigFile)
-	if isRelativeFilepath(cfg.InputFolderPath) {
-		cfg.InputFolderPath = filepath.Join(configDir, cfg.InputFolderPath)
+	for i, path := range cfg.InputFolderPaths {
+		if isRelativeFilepath(path) {
+			cfg.InputFolderPaths[i] = filepath.Join(configDir, path)
+		}
 	}
 	if isRelativeFilepath(cfg.OutputFile) {
 		cfg.OutputFile = filepath.Join(configDir, cfg.OutputFile)
@@ -122,8 +150,8 @@ func (o *Config) Validate() error {
 		errs = append(errs, errors.New("module_name must be specified"))
 	}
 
-	if o.ModelsPkg == "" {
-		errs = append(errs, errors.New("model_package must be specified"))
+	if len(o.InputFolderPaths) == 0 {
+		errs = append(errs, errors.New("input_folder_path must be specified"))
 	}
 
 	if o.QueryPkg == "" {
@@ -134,17 +162,37 @@ func (o *Config) Validate() error {
 		errs = append(errs, errors.New("output_file_path must be specified"))
 	}
 
+	if o.Concurrency < 0 {
+		errs = append(errs, errors.New("concurrency must be positive"))
+	}
+
+	if o.MaxExpandDepth == 0 {
+		o.MaxExpandDepth = DefaultMaxExpandDepth
+	} else if o.MaxExpandDepth < 0 {
+		errs = append(errs, errors.New("max_expand_depth must be positive"))
+	}
+
+	switch o.NamingStrategy {
+	case "":
+		o.NamingStrategy = SnakeCase
+	case SnakeCase, CamelCase, KebabCase:
+	default:
+		errs = append(errs, fmt.Errorf("naming_strategy must be one of %v, %v, or %v", SnakeCase, CamelCase, KebabCase))
+	}
+
 	err := errors.Join(errs...)
 	if err != nil {
 		return fmt.Errorf("failed to validate configuration: %w", err)
 	}
 
-	o.InputFolderPath, err = filepath.Abs(o.InputFolderPath)
-	if err != nil {
-		return err
-	}
-	if _, err := os.Stat(o.InputFolderPath); err != nil {
-		return err
+	for i, path := range o.InputFolderPaths {
+		o.InputFolderPaths[i], err = filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(PackageDir(o.InputFolderPaths[i])); err != nil {
+			return err
+		}
 	}
 
 	o.OutputFile, err = filepath.Abs(o.OutputFile)
@@ -152,6 +200,10 @@ func (o *Config) Validate() error {
 		return err
 	}
 
+	if err := o.validateQueryPackage(); err != nil {
+		return err
+	}
+
 	if o.OpenApiFile != "" {
 		o.OpenApiFile, err = filepath.Abs(o.OpenApiFile)
 		if err != nil {
@@ -194,6 +246,69 @@ func (o *Config) Validate() error {
 		return err
 	}
 
+	if o.PaginationConfiguration == nil {
+		o.PaginationConfiguration = &PaginationConfiguration{}
+	}
+	if err := o.PaginationConfiguration.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The query packages that passed validateQueryPackage, so watching doesn't load
+// the package on every rebuild. Failures aren't cached, so a fixed package is
+// checked again.
+var validQueryPackages sync.Map
+
+// Check that the query package can be loaded and was generated with context
+// support, since the generated repositories pass each request's context to gorm gen
+func (o *Config) validateQueryPackage() error {
+	dir := PackageDir(o.InputFolderPaths[0])
+	key := [2]string{dir, o.QueryPkg}
+	if _, ok := validQueryPackages.Load(key); ok {
+		return nil
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+		Dir:  dir,
+	}, o.QueryPkg)
+	if err != nil {
+		return fmt.Errorf("failed to validate configuration: failed to load query_package %v: %w", o.QueryPkg, err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Types == nil {
+		return fmt.Errorf("failed to validate configuration: query_package %v not found", o.QueryPkg)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		errs := []error{}
+		for _, err := range pkgs[0].Errors {
+			errs = append(errs, err)
+		}
+		return fmt.Errorf("failed to validate configuration: failed to load query_package %v: %w", o.QueryPkg, errors.Join(errs...))
+	}
+
+	var queryStruct *types.Struct
+	if query := pkgs[0].Types.Scope().Lookup("Query"); query != nil {
+		queryStruct, _ = query.Type().Underlying().(*types.Struct)
+	}
+	if queryStruct == nil {
+		return fmt.Errorf("failed to validate configuration: query_package %v has no Query struct, it must be generated by gorm gen", o.QueryPkg)
+	}
+	for i := 0; i < queryStruct.NumFields(); i++ {
+		modelStruct, ok := queryStruct.Field(i).Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		// gen.WithoutContext embeds the DAO, so queries can skip WithContext
+		for j := 0; j < modelStruct.NumFields(); j++ {
+			field := modelStruct.Field(j)
+			if field.Embedded() && strings.HasSuffix(field.Name(), "Do") {
+				return fmt.Errorf("failed to validate configuration: query_package %v was generated with gen.WithoutContext, which the generated repositories don't support", o.QueryPkg)
+			}
+		}
+	}
+	validQueryPackages.Store(key, true)
 	return nil
 }
 
@@ -216,6 +331,72 @@ func (c *RepositoryConfiguration) Validate() error {
 	return nil
 }
 
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+type PaginationConfiguration struct {
+	// The number of results returned when a request does not specify a limit, 100 is default
+	DefaultPageSize int `yaml:"default_page_size,omitempty"`
+	// The largest limit a request may specify, 1000 is default
+	MaxPageSize int `yaml:"max_page_size,omitempty"`
+}
+
+func (c *PaginationConfiguration) Validate() error {
+	if c.DefaultPageSize == 0 {
+		c.DefaultPageSize = DefaultPageSize
+	}
+	if c.MaxPageSize == 0 {
+		c.MaxPageSize = MaxPageSize
+		if c.DefaultPageSize > c.MaxPageSize {
+			c.MaxPageSize = c.DefaultPageSize
+		}
+	}
+
+	var errs []error
+	if c.DefaultPageSize < 0 {
+		errs = append(errs, errors.New("default_page_size must be positive"))
+	}
+	if c.MaxPageSize < 0 {
+		errs = append(errs, errors.New("max_page_size must be positive"))
+	}
+	if c.DefaultPageSize > c.MaxPageSize {
+		errs = append(errs, errors.New("default_page_size must not be greater than max_page_size"))
+	}
+	err := errors.Join(errs...)
+	if err != nil {
+		return fmt.Errorf("failed to validate configuration: %w", err)
+	}
+	return nil
+}
+
 func isRelativeFilepath(fp string) bool {
 	return !strings.HasPrefix("/", fp)
 }
+
+// A list of strings that can also be a single string in YAML
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*l = StringList{s}
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// Get the directory of a folder path or package pattern, e.g. ./model/... -> ./model
+func PackageDir(path string) string {
+	if path == "..." {
+		return "."
+	}
+	return strings.TrimSuffix(path, string(filepath.Separator)+"...")
+}
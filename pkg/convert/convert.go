@@ -31,7 +31,10 @@ func ConvertFieldNamed(
 	dst *entity.GormModelMetadata,
 	from, to string,
 ) string {
-	dstField := dst.GetField(field.Name)
+	dstField := dst.GetJsonField(field.JsonName)
+	if dstField == nil {
+		return ""
+	}
 
 	if field.MapApiFunc != nil {
 		return fmt.Sprintf("%v.%v = model.%v(%v.%v)", to, dstField.Name, *field.MapApiFunc, from, field.Name)
@@ -81,6 +84,12 @@ func ConvertFieldNamed(
 	case *types.Named:
 		switch d := dstType.(type) {
 		case *types.Named:
+			if field.Enum != nil || dstField.Enum != nil {
+				if convert := convertEnum(s, d, isSrcPtr, isDstPtr); convert != "" {
+					return fmt.Sprintf("%v(&%v.%v, %v.%v)", convert, to, dstField.Name, from, field.Name)
+				}
+			}
+
 			if s.Obj().Name() == "Time" && d.Obj().Name() == "DeletedAt" {
 				return fmt.Sprintf("%v.%v = convertTimeToGormDeletedAt(%v.%v)", to, dstField.Name, from, field.Name)
 			} else if d.Obj().Name() == "Time" && s.Obj().Name() == "DeletedAt" {
@@ -154,3 +163,29 @@ func ConvertFieldNamed(
 
 	return fmt.Sprintf("%v.%v = %v.%v", to, dstField.Name, from, field.Name)
 }
+
+// Get the mapper_util.tmpl function that converts between the enum types, like
+// the oapi-codegen type and the model type, or "" if they can't be converted
+func convertEnum(src *types.Named, dst *types.Named, isSrcPtr bool, isDstPtr bool) string {
+	s, ok := src.Underlying().(*types.Basic)
+	if !ok {
+		return ""
+	}
+	d, ok := dst.Underlying().(*types.Basic)
+	if !ok || isSrcPtr != isDstPtr {
+		return ""
+	}
+
+	var convert string
+	if s.Info()&types.IsString != 0 && d.Info()&types.IsString != 0 {
+		convert = "convertStringEnum"
+	} else if s.Info()&types.IsInteger != 0 && d.Info()&types.IsInteger != 0 {
+		convert = "convertIntEnum"
+	} else {
+		return ""
+	}
+	if isSrcPtr {
+		convert += "Ptr"
+	}
+	return convert
+}
@@ -8,10 +8,14 @@ import (
 )
 
 type GormModelMetadata struct {
-	Name     string
-	Fields   []*GormModelField
-	Embedded []*GormModelMetadata
-	IsApi    bool
+	Name string
+	// The import path of the model's package
+	Package string `json:",omitempty"`
+	// The type's doc comment
+	Description string `json:",omitempty"`
+	Fields      []*GormModelField
+	Embedded    []*GormModelMetadata
+	IsApi       bool
 
 	t types.Type
 }
@@ -44,15 +48,32 @@ func (m *GormModelMetadata) GetField(name string) *GormModelField {
 	return field
 }
 
+// Get the field with the API property name, or nil if there isn't one
+func (m *GormModelMetadata) GetJsonField(jsonName string) *GormModelField {
+	for _, field := range m.AllFields() {
+		if field.JsonName == jsonName {
+			return field
+		}
+	}
+	return nil
+}
+
 type GormModelField struct {
 	Name        string
 	Type        string
 	Tag         string
 	OpenApiType string
+	// The field's doc comment
+	Description string `json:",omitempty"`
+	// The name of the field's API property, or - if it's hidden. Set by the generator.
+	JsonName string `json:",omitempty"`
 
 	MapFunc    *string
 	MapApiFunc *string
 
+	// Set if the field's type is a named string or integer type with constants
+	Enum *GormEnumMetadata `json:",omitempty"`
+
 	Parent *GormModelMetadata `json:"-"`
 
 	t types.Type
@@ -84,3 +105,23 @@ func (f *GormModelField) GetGoType() string {
 	}
 	return f.Type
 }
+
+// A named string or integer type, like type Status string, and the constants
+// declared with it
+type GormEnumMetadata struct {
+	Name string
+	// The import path of the type's package
+	Package string
+	// The underlying basic type, like string or int
+	Type string
+	// The type's doc comment
+	Description string `json:",omitempty"`
+	Values      []GormEnumValue
+}
+
+type GormEnumValue struct {
+	// The name of the constant
+	Name string
+	// The constant's value as a JSON literal
+	Value string
+}
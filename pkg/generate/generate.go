@@ -1,859 +1,1866 @@
-package generate
-
-import (
-	"bufio"
-	"bytes"
-	"context"
-	"embed"
-	"errors"
-	"fmt"
-	"go/types"
-	"io/fs"
-	"log"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"regexp"
-	"slices"
-	"strings"
-	"text/template"
-
-	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/joeriddles/goalesce/pkg/config"
-	"github.com/joeriddles/goalesce/pkg/convert"
-	"github.com/joeriddles/goalesce/pkg/entity"
-	"github.com/joeriddles/goalesce/pkg/parse"
-	"github.com/joeriddles/goalesce/pkg/utils"
-	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
-	codegen_util "github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
-	"golang.org/x/tools/imports"
-	"gopkg.in/yaml.v2"
-)
-
-//go:embed templates
-var templates embed.FS
-
-type Generator interface {
-	Generate(metadatas []*entity.GormModelMetadata) error
-}
-
-type generator struct {
-	logger            *log.Logger
-	cfg               *config.Config
-	templates         *template.Template
-	relativePkgPath   string
-	typesPackage      *string
-	repositoryPackage string
-}
-
-func NewGenerator(logger *log.Logger, cfg *config.Config) (Generator, error) {
-	modulePath, err := utils.FindGoMod(cfg.OutputFile, cfg.ModuleName)
-	if err != nil {
-		return nil, err
-	}
-	moduleRootPath := filepath.Dir(modulePath)
-	relPath, err := filepath.Rel(moduleRootPath, cfg.OutputFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var typesPackage *string = nil
-	defaultOutputFile := filepath.Join(cfg.OutputFile, "api", "types.gen.go")
-	if cfg.TypesCodegen.OutputFile != defaultOutputFile {
-		relPkg, err := filepath.Rel(moduleRootPath, cfg.TypesCodegen.OutputFile)
-		if err != nil {
-			return nil, err
-		}
-		pkg := filepath.Join(cfg.ModuleName, relPkg)
-		pkg = filepath.Dir(pkg) // remove filename
-		pkg = filepathToPackage(pkg)
-		typesPackage = &pkg
-	}
-
-	var repositoryPackage string = filepath.Join(cfg.ModuleName, relPath, "repository")
-	defaultRepositoryOutputFile := filepath.Join(cfg.OutputFile, "repository")
-	if cfg.RepositoryConfiguration.OutputFile != defaultRepositoryOutputFile {
-		relPkg, err := filepath.Rel(moduleRootPath, cfg.RepositoryConfiguration.OutputFile)
-		if err != nil {
-			return nil, err
-		}
-		repositoryPackage = filepath.Join(cfg.ModuleName, relPkg)
-	}
-
-	// Normalize filepath to Go package
-	repositoryPackage = filepathToPackage(repositoryPackage)
-
-	g := &generator{
-		logger:            logger,
-		cfg:               cfg,
-		relativePkgPath:   relPath,
-		typesPackage:      typesPackage,
-		repositoryPackage: repositoryPackage,
-	}
-
-	t := template.New("gorm_oapi_codegen")
-	if err := g.loadTemplates(templates, t); err != nil {
-		return nil, err
-	}
-	g.templates = t
-
-	return g, nil
-}
-
-// Generate from GORM model metadata
-func (g *generator) Generate(metadatas []*entity.GormModelMetadata) error {
-	if g.cfg.ClearOutputDir {
-		if err := os.RemoveAll(g.cfg.OutputFile); err != nil {
-			return err
-		}
-	}
-
-	if err := createDirs(
-		g.cfg.OutputFile,
-		filepath.Join(g.cfg.OutputFile, "api"),
-		filepath.Join(g.cfg.RepositoryConfiguration.OutputFile),
-	); err != nil {
-		return err
-	}
-
-	if err := g.generateOpenApiYaml(metadatas); err != nil {
-		return err
-	}
-
-	if err := g.runCodegenTool(); err != nil {
-		return err
-	}
-
-	// parse generated API types
-	parser := parse.NewParser(g.logger, g.cfg)
-	apiMetadatas, err := parser.Parse(filepath.Dir(g.cfg.TypesCodegen.OutputFile))
-	if err != nil {
-		return err
-	}
-
-	for _, metadata := range metadatas {
-		apiMetadata, err := utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
-			return m.Name == metadata.Name
-		})
-		if err != nil {
-			g.logger.Printf("could not find apiMetadata for %v", metadata.Name)
-			continue
-		}
-		apiMetadata.IsApi = true
-
-		for _, apiField := range apiMetadata.AllFields() {
-			field := metadata.GetField(apiField.Name)
-			apiField.MapFunc = field.MapApiFunc
-			apiField.MapApiFunc = field.MapFunc
-		}
-
-		createStr := fmt.Sprintf("Create%v", metadata.Name)
-		createApiMetadata, _ := utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
-			return m.Name == createStr
-		})
-		updateStr := fmt.Sprintf("Update%v", metadata.Name)
-		updateApiMetadata, _ := utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
-			return m.Name == updateStr
-		})
-
-		if err := g.generateMapper(metadata, apiMetadata); err != nil {
-			return err
-		}
-
-		// Don't generate anything but the mapper for excluded models
-		if slices.Contains(g.cfg.ExcludeModels, metadata.Name) {
-			continue
-		}
-
-		for _, createApiField := range createApiMetadata.AllFields() {
-			field := metadata.GetField(createApiField.Name)
-			createApiField.MapFunc = field.MapApiFunc
-			createApiField.MapApiFunc = field.MapFunc
-		}
-		for _, updateApiField := range updateApiMetadata.AllFields() {
-			field := metadata.GetField(updateApiField.Name)
-			updateApiField.MapFunc = field.MapApiFunc
-			updateApiField.MapApiFunc = field.MapFunc
-		}
-
-		if err := g.generateRepository(metadata); err != nil {
-			return err
-		}
-
-		getFilterStr := fmt.Sprintf("Get%vParams", metadata.Name)
-		getFilterMetadata, _ := utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
-			return m.Name == getFilterStr
-		})
-
-		for _, filterField := range getFilterMetadata.Fields {
-			modelField, _ := utils.First(metadata.Fields, func(f *entity.GormModelField) bool {
-				return f.Name == filterField.Name
-			})
-			filterField.MapFunc = modelField.MapFunc
-			filterField.MapApiFunc = modelField.MapApiFunc
-		}
-		if err := g.generateController(metadata, createApiMetadata, updateApiMetadata, getFilterMetadata); err != nil {
-			return err
-		}
-	}
-
-	filteredMetadatas := []*entity.GormModelMetadata{}
-	for _, metadata := range metadatas {
-		if !slices.Contains(g.cfg.ExcludeModels, metadata.Name) {
-			filteredMetadatas = append(filteredMetadatas, metadata)
-		}
-	}
-
-	if g.cfg.GenerateServer {
-		if err := g.generateServer(filteredMetadatas); err != nil {
-			return err
-		}
-	}
-
-	if g.cfg.GenerateMain {
-		if err := g.generateMain(); err != nil {
-			return err
-		}
-	}
-
-	if err := g.generateMapperUtil(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (g *generator) generateOpenApiYaml(metadatas []*entity.GormModelMetadata) error {
-	for _, metadata := range metadatas {
-		_, err := g.generateOpenApiRoutes(metadata)
-		if err != nil {
-			return err
-		}
-	}
-	if err := g.generateOpenApiBase(metadatas); err != nil {
-		return err
-	}
-	if err := g.combineOpenApiFiles(); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (g *generator) runCodegenTool() error {
-	g.cfg.TypesCodegen.Configuration.OutputOptions.NameNormalizer = "ToCamelCaseWithInitialisms"
-	g.cfg.ServerCodegen.Configuration.OutputOptions.NameNormalizer = "ToCamelCaseWithInitialisms"
-
-	swagger, err := codegen_util.LoadSwagger(filepath.Join(g.cfg.OutputFile, "openapi.yaml"))
-	if err != nil {
-		return err
-	}
-	code, err := codegen.Generate(swagger, g.cfg.TypesCodegen.Configuration)
-	if err != nil {
-		return err
-	}
-	if err = os.WriteFile(g.cfg.TypesCodegen.OutputFile, []byte(code), 0o644); err != nil {
-		return err
-	}
-
-	code, err = codegen.Generate(swagger, g.cfg.ServerCodegen.Configuration)
-	if err != nil {
-		return err
-	}
-	if err = os.WriteFile(g.cfg.ServerCodegen.OutputFile, []byte(code), 0o644); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (g *generator) combineOpenApiFiles() error {
-	outputFp := filepath.Join(g.cfg.OutputFile, "openapi.yaml")
-	f, err := os.Create(outputFp)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	baseFp := filepath.Join(g.cfg.OutputFile, "openapi_base.gen.yaml")
-
-	args := []string{
-		"bundle",
-		"--output",
-		outputFp,
-		baseFp,
-	}
-
-	if output, err := runNpxCommand(
-		"@redocly/openapi-cli@latest",
-		args...,
-	); err != nil {
-		os.Stderr.WriteString(output)
-		return err
-	}
-
-	if g.cfg.PruneYaml {
-		entries, err := os.ReadDir(g.cfg.OutputFile)
-		if err != nil {
-			return err
-		}
-		for _, entry := range entries {
-			filename := entry.Name()
-			if strings.HasSuffix(filename, ".yaml") && filename != "openapi.yaml" {
-				if err := os.Remove(filepath.Join(g.cfg.OutputFile, filename)); err != nil {
-					return err
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-func (g *generator) generateOpenApiBase(metadatas []*entity.GormModelMetadata) error {
-	fp := filepath.Join(g.cfg.OutputFile, "openapi_base.gen.yaml")
-	f, err := os.Create(fp)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	var doc *openapi3.T
-
-	if g.cfg.OpenApiFile != "" {
-		loader := openapi3.NewLoader()
-		doc, err = loader.LoadFromFile(g.cfg.OpenApiFile)
-		if err != nil {
-			return err
-		}
-	} else {
-		doc = &openapi3.T{
-			OpenAPI: "3.0.0",
-			Info: &openapi3.Info{
-				Version: "1.0.0",
-				Title:   "Generated API",
-			},
-			Paths: openapi3.NewPaths(),
-		}
-	}
-
-	for _, metadata := range metadatas {
-		if slices.Contains(g.cfg.ExcludeModels, metadata.Name) {
-			continue
-		}
-
-		doc.Paths.Set(fmt.Sprintf("/%v/", utils.ToHtmlCase(metadata.Name)), &openapi3.PathItem{
-			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/~1", utils.ToSnakeCase(metadata.Name)),
-		})
-		doc.Paths.Set(fmt.Sprintf("/%v/{id}/", utils.ToHtmlCase(metadata.Name)), &openapi3.PathItem{
-			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/~1%%7Bid%%7D~1", utils.ToSnakeCase(metadata.Name)),
-		})
-		doc.Paths.Set(fmt.Sprintf("/%v/batch/", utils.ToHtmlCase(metadata.Name)), &openapi3.PathItem{
-			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/~1batch~1", utils.ToSnakeCase(metadata.Name)),
-		})
-	}
-
-	if err := doc.Paths.Validate(context.Background()); err != nil {
-		return err
-	}
-
-	yamlContent, err := yaml.Marshal(doc)
-	if err != nil {
-		return err
-	}
-
-	if _, err = f.Write(yamlContent); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (g *generator) generateOpenApiRoutes(metadata *entity.GormModelMetadata) (string, error) {
-	fp := filepath.Join(g.cfg.OutputFile, fmt.Sprintf("%v.gen.yaml", utils.ToSnakeCase(metadata.Name)))
-	f, err := os.Create(fp)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	w := bufio.NewWriter(f)
-	g.templates.ExecuteTemplate(w, "openapi_controller.yaml", metadata)
-	w.Flush()
-
-	return fp, nil
-}
-
-func (g *generator) generateController(
-	metadata *entity.GormModelMetadata,
-	createApiMetadata *entity.GormModelMetadata,
-	updateApiMetadata *entity.GormModelMetadata,
-	filterMetadata *entity.GormModelMetadata,
-) error {
-	fp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_controller.gen.go", utils.ToSnakeCase(metadata.Name)))
-
-	convertToFilter := func(field *entity.GormModelField) string {
-		return convert.ConvertFieldNamed(g.templates, field, metadata, "request.Params", "filters")
-	}
-	g.templates.Funcs(template.FuncMap{
-		"ConvertToFilter": convertToFilter,
-	})
-
-	template := "controller.tmpl"
-	if g.cfg.ServerCodegen.Generate.EchoServer {
-		template = "echo_controller.tmpl"
-	}
-
-	return g.generateGo(
-		fp,
-		template,
-		map[string]interface{}{
-			"package":              g.cfg.ServerCodegen.PackageName,
-			"queryPackage":         g.cfg.QueryPkg,
-			"typesPackage":         g.typesPackage,
-			"repositoryImportPath": g.repositoryPackage,
-			"model":                metadata,
-			"createApi":            createApiMetadata,
-			"updateApi":            updateApiMetadata,
-			"filterMetadata":       filterMetadata,
-		},
-	)
-}
-
-func (g *generator) generateServer(metadatas []*entity.GormModelMetadata) error {
-	fp := filepath.Join(g.cfg.OutputFile, "api", "server.gen.go")
-
-	template := "server.tmpl"
-	if g.cfg.ServerCodegen.Generate.EchoServer {
-		template = "echo_server.tmpl"
-	}
-
-	return g.generateGo(
-		fp,
-		template,
-		map[string]interface{}{
-			"package":      g.cfg.ServerCodegen.PackageName,
-			"queryPackage": g.cfg.QueryPkg,
-			"typesPackage": g.typesPackage,
-			"metadatas":    metadatas,
-		},
-	)
-}
-
-func (g *generator) generateRepository(metadata *entity.GormModelMetadata) error {
-	filename := fmt.Sprintf("%v_repository.gen.go", utils.ToSnakeCase(metadata.Name))
-	fp := filepath.Join(g.cfg.RepositoryConfiguration.OutputFile, filename)
-	return g.generateGo(
-		fp,
-		"repository.tmpl",
-		map[string]interface{}{
-			"pkg":      g.cfg.ModelsPkg,
-			"queryPkg": g.cfg.QueryPkg,
-			"model":    metadata,
-		},
-	)
-}
-
-func (g *generator) generateMapper(
-	metadata *entity.GormModelMetadata,
-	apiMetadata *entity.GormModelMetadata,
-) error {
-	fp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_mapper.gen.go", utils.ToSnakeCase(metadata.Name)))
-	apiFp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_api_mapper.gen.go", utils.ToSnakeCase(metadata.Name)))
-
-	convertToModel := func(field *entity.GormModelField) string {
-		return convert.ConvertField(g.templates, field, metadata)
-	}
-	convertToApi := func(field *entity.GormModelField) string {
-		return convert.ConvertField(g.templates, field, apiMetadata)
-	}
-	g.templates.Funcs(template.FuncMap{
-		"ConvertToModel": convertToModel,
-		"ConvertToApi":   convertToApi,
-	})
-
-	errs := []error{}
-
-	err := g.generateGo(
-		fp,
-		"model_mapper.tmpl",
-		map[string]interface{}{
-			"package":      g.cfg.ServerCodegen.PackageName,
-			"typesPackage": g.typesPackage,
-			"pkg":          g.cfg.ModelsPkg,
-			"Name":         metadata.Name,
-			"src":          apiMetadata,
-			"dst":          metadata,
-		},
-	)
-	if err != nil {
-		errs = append(errs, err)
-	}
-
-	err = g.generateGo(
-		apiFp,
-		"api_mapper.tmpl",
-		map[string]interface{}{
-			"package":      g.cfg.ServerCodegen.PackageName,
-			"typesPackage": g.typesPackage,
-			"pkg":          g.cfg.ModelsPkg,
-			"Name":         apiMetadata.Name + "Api",
-			"src":          metadata,
-			"dst":          apiMetadata,
-		},
-	)
-	if err != nil {
-		errs = append(errs, err)
-	}
-	if err != nil {
-		errs = append(errs, err)
-	}
-
-	if len(errs) > 0 {
-		return errors.Join(errs...)
-	}
-
-	return nil
-}
-
-func (g *generator) generateMain() error {
-	fp := filepath.Join(g.cfg.OutputFile, "main.go")
-	apiImportPath := filepath.Join(g.cfg.ModuleName, g.relativePkgPath, "api")
-	return g.generateGo(
-		fp,
-		"main.tmpl",
-		map[string]interface{}{
-			"apiImportPath": apiImportPath,
-		},
-	)
-}
-
-func (g *generator) generateMapperUtil() error {
-	fp := filepath.Join(g.cfg.OutputFile, "api", "mapper_util.gen.go")
-	return g.generateGo(
-		fp,
-		"mapper_util.tmpl",
-		map[string]interface{}{
-			"package": g.cfg.ServerCodegen.PackageName,
-		},
-	)
-}
-
-// Generate formatted Go code at the filepath with the template
-func (g *generator) generateGo(fp string, template string, data any) error {
-	f, err := os.Create(fp)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	// Write the template to in-memory buffer
-	var b bytes.Buffer
-	w := bufio.NewWriter(&b)
-	if err := g.templates.ExecuteTemplate(w, template, data); err != nil {
-		return err
-	}
-	if err := w.Flush(); err != nil {
-		return err
-	}
-
-	// Format code and fix missing imports
-	unformattedCode := b.Bytes()
-	code, err := imports.Process(fp, unformattedCode, &imports.Options{})
-	if err != nil {
-		fmt.Printf("Failed to format code:\n%v", string(unformattedCode))
-		return err
-	}
-
-	// Write to file
-	w = bufio.NewWriter(f)
-	_, err = w.Write(code)
-	if err != nil {
-		return err
-	}
-	if err := w.Flush(); err != nil {
-		return err
-	}
-
-	// Format imports with goimports tool
-	cmd := exec.Command("goimports", "-w", fp)
-	_, err = cmd.CombinedOutput()
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// loadTemplates loads all of our template files into a text/template. The
-// path of template is relative to the templates directory.
-func (g *generator) loadTemplates(src embed.FS, t *template.Template) error {
-	getTypesNamespace := func() string {
-		if g.typesPackage == nil {
-			return ""
-		}
-		return "types."
-	}
-
-	funcMap := template.FuncMap{
-		"ToLower":            strings.ToLower,
-		"ToCamelCase":        utils.ToCamelCase,
-		"ToSnakeCase":        utils.ToSnakeCase,
-		"ToHtmlCase":         utils.ToHtmlCase,
-		"ToPascalCase":       utils.ToPascalCase,
-		"ShouldExcludeField": g.shouldExcludeField,
-		"ToOpenApiType":      toOpenApiType,
-		"MapToModelType":     mapToModelType,
-		"MapToApiType":       mapToApiType,
-		"IsSimpleType":       utils.IsSimpleType,
-		"IsComplexType":      utils.IsComplexType,
-		"IsNullable":         isNullable,
-		"Not":                not,
-		"Types":              getTypesNamespace,
-		"WrapID":             wrapID,
-		"ShouldCreateField":  shouldCreateField,
-		"GetGormQueryType":   getGormQueryType,
-		"ToPtr":              toPtr,
-		// will be replaced per model
-		"ConvertToModel":           func() string { return "" },
-		"ConvertToApi":             func() string { return "" },
-		"ConvertToModelFromCreate": func() string { return "" },
-		"ConvertToFilter":          func() string { return "" },
-	}
-
-	err := fs.WalkDir(src, "templates", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return fmt.Errorf("error walking directory %s: %w", path, err)
-		}
-		if d.IsDir() {
-			return nil
-		}
-
-		buf, err := src.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("error reading file '%s': %w", path, err)
-		}
-
-		templateName := strings.TrimPrefix(path, "templates/")
-		tmpl := t.New(templateName).Funcs(funcMap)
-		_, err = tmpl.Parse(string(buf))
-		if err != nil {
-			return fmt.Errorf("parsing template '%s': %w", path, err)
-		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-
-	if g.cfg.RepositoryConfiguration.Template != nil {
-		path := *g.cfg.RepositoryConfiguration.Template
-		buf, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("error reading file '%s': %w", path, err)
-		}
-
-		templateName := "repository.tmpl"
-		tmpl := t.New(templateName).Funcs(funcMap)
-		_, err = tmpl.Parse(string(buf))
-		if err != nil {
-			return fmt.Errorf("parsing template '%s': %w", path, err)
-		}
-	}
-
-	for name, path := range g.cfg.UserTemplates {
-		utpl := t.New(name)
-		bytes, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("error loading user-provided template %q: %w", name, err)
-		}
-		txt := string(bytes)
-		_, err = utpl.Parse(txt)
-		if err != nil {
-			return fmt.Errorf("error parsing user-provided template %q: %w", name, err)
-		}
-	}
-
-	return nil
-}
-
-func runNpxCommand(command string, args ...string) (string, error) {
-	cmd := exec.Command("npx", append([]string{command}, args...)...)
-	output, err := cmd.CombinedOutput()
-	return string(output), err
-}
-
-// Map the model field to a type for mapping to a model
-func mapToModelType(field entity.GormModelField) string {
-	result := ""
-	apiField := utils.ToPascalCase(field.Name)
-
-	switch field.Type {
-	case "uint":
-		result = fmt.Sprintf("%v: uint(obj.%v),", field.Name, apiField)
-	case "gorm.DeletedAt":
-		result = fmt.Sprintf("%v: gorm.DeletedAt{Time: *obj.%v},", field.Name, apiField)
-	default:
-		if utils.IsSimpleType(field.Type) {
-			result = fmt.Sprintf("%v: obj.%v,", field.Name, apiField)
-		} else {
-			// TODO(joeriddles): map complex types
-			result = ""
-		}
-	}
-
-	return result
-}
-
-// Map the model field to a type for mapping to an API model
-func mapToApiType(field entity.GormModelField) string {
-	result := ""
-	apiField := utils.ToPascalCase(field.Name)
-
-	switch field.Type {
-	case "uint":
-		result = fmt.Sprintf("%v: int(model.%v),", apiField, field.Name)
-	case "gorm.DeletedAt":
-		result = fmt.Sprintf("%v: func() *time.Time { if model.%v.Valid { return &model.%v.Time } else { return nil } }(),", apiField, field.Name, field.Name)
-	default:
-		result = fmt.Sprintf("%v: model.%v,", apiField, field.Name)
-	}
-
-	return result
-}
-
-var primaryKeyRegex *regexp.Regexp = regexp.MustCompile("gorm:\"(.*?)\"")
-
-// Whether the field should be excluded from create and update operations
-func (g *generator) shouldExcludeField(field entity.GormModelField) bool {
-	if slices.Contains(g.cfg.ExcludeFields, field.Name) {
-		return true
-	}
-
-	if field.Parent != nil {
-		if parentNamedType, ok := field.Parent.GetType().(*types.Named); ok {
-			if parentNamedType.Obj().Pkg().Name() == "gorm" && parentNamedType.Obj().Name() == "Model" {
-				return true
-			}
-		}
-	}
-
-	match := primaryKeyRegex.FindStringSubmatch(field.Tag)
-	if len(match) == 0 {
-		return false
-	}
-
-	gormParts := strings.Split(match[1], ";")
-	isPrimaryKey := slices.Contains(gormParts, "primaryKey")
-	isAutoCreateTime := slices.ContainsFunc(gormParts, func(p string) bool { return strings.HasPrefix(p, "autoCreateTime") })
-	isAutoUpdateTime := slices.ContainsFunc(gormParts, func(p string) bool { return strings.HasPrefix(p, "isAutoUpdateTime") })
-	return isPrimaryKey || isAutoCreateTime || isAutoUpdateTime
-}
-
-func toOpenApiType(field entity.GormModelField) *utils.OpenApiType {
-	if field.Tag != "" {
-		settings, err := utils.ParseGoalesceTagSettings(field.Tag)
-		if err == nil && len(settings) > 0 {
-			openApiType := &utils.OpenApiType{}
-
-			if typ, ok := settings["openapi_type"]; ok {
-				openApiType.Type = typ
-			}
-			if ref, ok := settings["openapi_ref"]; ok {
-				openApiType.Ref = &ref
-			}
-			if format, ok := settings["openapi_format"]; ok {
-				openApiType.Format = &format
-			}
-			if nullable, ok := settings["openapi_nullable"]; ok {
-				openApiType.Nullable = strings.ToLower(nullable) == "true"
-			}
-
-			return openApiType
-		}
-	}
-
-	return utils.ToOpenApiType(field.Type)
-}
-
-func wrapID(model *entity.GormModelMetadata) string {
-	result := "id"
-
-	idField, err := utils.First(model.AllFields(), func(f *entity.GormModelField) bool {
-		return f.Name == "ID"
-	})
-	if err == nil {
-		if basicType, ok := idField.GetType().(*types.Basic); ok {
-			wrapper := basicType.Name()
-			result = fmt.Sprintf("%v(id)", wrapper)
-		}
-	}
-
-	return result
-}
-
-func getGormQueryType(field *entity.GormModelField) string {
-	t := field.GetGoType()
-	if t == "time.Duration" {
-		t = "int64"
-	}
-	return t
-}
-
-func toPtr(t string) string {
-	if !strings.HasPrefix(t, "*") {
-		t = "*" + t
-	}
-	return t
-}
-
-func not(v bool) bool {
-	return !v
-}
-
-func isNullable(t string) bool {
-	openApiType := utils.ToOpenApiType(t)
-	return openApiType.Nullable
-}
-
-func shouldCreateField(f entity.GormModelField) bool {
-	return !strings.HasPrefix(f.Type, "*") && !strings.HasPrefix(f.Type, "[]")
-
-	// typ := f.GetType()
-
-	// if f.MapFunc != nil {
-	// 	mapFuncSig := f.GetMapFuncSignature()
-	// 	resType := mapFuncSig.Results().At(0)
-	// 	typ = resType.Type()
-	// }
-
-	// switch t := typ.(type) {
-	// case *types.Basic:
-	// 	return true
-	// case *types.Pointer:
-	// 	switch t.Elem().(type) {
-	// 	case *types.Basic:
-	// 		return true
-	// 	default:
-	// 		return false
-	// 	}
-	// case *types.Array, *types.Slice, *types.Map, *types.Chan, *types.Struct, *types.Tuple, *types.Signature, *types.Named, *types.Interface:
-	// 	return false
-	// default:
-	// 	panic(fmt.Sprintf("impossible: %T", t))
-	// }
-}
-
-func createDirs(paths ...string) error {
-	for _, path := range paths {
-		if err := os.MkdirAll(path, os.ModePerm); err != nil {
-			if err.Error() != "mkdir generated: file exists" {
-				return fmt.Errorf("could not create path %v: %v", path, err)
-			}
-		}
-	}
-	return nil
-}
-
-func filepathToPackage(path string) string {
-	return strings.ReplaceAll(path, "\\", "/")
-}
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/types"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/joeriddles/goalesce/pkg/config"
+	"github.com/joeriddles/goalesce/pkg/convert"
+	"github.com/joeriddles/goalesce/pkg/entity"
+	"github.com/joeriddles/goalesce/pkg/parse"
+	"github.com/joeriddles/goalesce/pkg/utils"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"golang.org/x/tools/imports"
+	"gopkg.in/yaml.v2"
+	gofumpt "mvdan.cc/gofumpt/format"
+)
+
+//go:embed templates
+var templates embed.FS
+
+type Generator interface {
+	Generate(metadatas []*entity.GormModelMetadata) error
+}
+
+type generator struct {
+	logger            *log.Logger
+	cfg               *config.Config
+	templates         *template.Template
+	relativePkgPath   string
+	typesPackage      *string
+	repositoryPackage string
+	// Hash of each loaded template by name
+	templateHashes map[string]string
+	// Every parsed model, which associations are looked up in
+	models []*entity.GormModelMetadata
+	// The manifest from the last run, if any, and the one for this run
+	previous *manifest
+	manifest *manifest
+	// Guards manifest, which is updated while models are generated concurrently
+	mu sync.Mutex
+}
+
+func NewGenerator(logger *log.Logger, cfg *config.Config) (Generator, error) {
+	modulePath, err := utils.FindGoMod(cfg.OutputFile, cfg.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+	moduleRootPath := filepath.Dir(modulePath)
+	relPath, err := filepath.Rel(moduleRootPath, cfg.OutputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var typesPackage *string = nil
+	defaultOutputFile := filepath.Join(cfg.OutputFile, "api", "types.gen.go")
+	if cfg.TypesCodegen.OutputFile != defaultOutputFile {
+		relPkg, err := filepath.Rel(moduleRootPath, cfg.TypesCodegen.OutputFile)
+		if err != nil {
+			return nil, err
+		}
+		pkg := filepath.Join(cfg.ModuleName, relPkg)
+		pkg = filepath.Dir(pkg) // remove filename
+		pkg = filepathToPackage(pkg)
+		typesPackage = &pkg
+	}
+
+	var repositoryPackage string = filepath.Join(cfg.ModuleName, relPath, "repository")
+	defaultRepositoryOutputFile := filepath.Join(cfg.OutputFile, "repository")
+	if cfg.RepositoryConfiguration.OutputFile != defaultRepositoryOutputFile {
+		relPkg, err := filepath.Rel(moduleRootPath, cfg.RepositoryConfiguration.OutputFile)
+		if err != nil {
+			return nil, err
+		}
+		repositoryPackage = filepath.Join(cfg.ModuleName, relPkg)
+	}
+
+	// Normalize filepath to Go package
+	repositoryPackage = filepathToPackage(repositoryPackage)
+
+	g := &generator{
+		logger:            logger,
+		cfg:               cfg,
+		relativePkgPath:   relPath,
+		typesPackage:      typesPackage,
+		repositoryPackage: repositoryPackage,
+		templateHashes:    map[string]string{},
+	}
+
+	t := template.New("gorm_oapi_codegen")
+	if err := g.loadTemplates(templates, t); err != nil {
+		return nil, err
+	}
+	g.templates = t
+
+	return g, nil
+}
+
+// Generate from GORM model metadata
+//
+// Files whose inputs haven't changed since the last run are not re-rendered,
+// and files for models that no longer exist are removed.
+func (g *generator) Generate(metadatas []*entity.GormModelMetadata) error {
+	previous, err := readManifest(g.cfg.OutputFile)
+	if err != nil {
+		g.logger.Printf("ignoring invalid manifest: %v", err)
+		previous = nil
+	}
+	if previous != nil && previous.Version != generatorVersion() {
+		previous = nil
+	}
+
+	if g.cfg.ClearOutputDir && previous == nil {
+		if err := os.RemoveAll(g.cfg.OutputFile); err != nil {
+			return err
+		}
+	}
+	// Remove the old manifest so a failed run doesn't leave it out of date
+	if err := os.Remove(filepath.Join(g.cfg.OutputFile, manifestFilename)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	g.setJsonNames(metadatas)
+	g.models = metadatas
+
+	g.previous = previous
+	g.manifest = newManifest(g.templateHashes)
+	g.manifest.Models, err = hashModels(metadatas)
+	if err != nil {
+		return err
+	}
+
+	if err := createDirs(
+		g.cfg.OutputFile,
+		filepath.Join(g.cfg.OutputFile, "api"),
+		filepath.Join(g.cfg.RepositoryConfiguration.OutputFile),
+	); err != nil {
+		return err
+	}
+
+	if err := g.generateOpenApiYaml(metadatas); err != nil {
+		return err
+	}
+
+	if err := g.runCodegenTool(); err != nil {
+		return err
+	}
+
+	// Hash after running oapi-codegen, since it sets config defaults
+	g.manifest.Config, err = hashConfig(g.cfg)
+	if err != nil {
+		return err
+	}
+
+	// parse generated API types, ignoring any previously generated code in
+	// the same package
+	parser := parse.NewParser(g.logger, g.cfg)
+	apiMetadatas, err := parser.Parse(g.cfg.TypesCodegen.OutputFile)
+	if err != nil {
+		return err
+	}
+	g.setJsonNames(apiMetadatas)
+
+	jobs := []*modelJob{}
+	for _, metadata := range metadatas {
+		apiMetadata, err := utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
+			return m.Name == metadata.Name
+		})
+		if err != nil {
+			g.logger.Printf("could not find apiMetadata for %v", metadata.Name)
+			continue
+		}
+		apiMetadata.IsApi = true
+
+		for _, apiField := range apiMetadata.AllFields() {
+			field := metadata.GetJsonField(apiField.JsonName)
+			if field == nil {
+				continue
+			}
+			apiField.MapFunc = field.MapApiFunc
+			apiField.MapApiFunc = field.MapFunc
+		}
+
+		job := &modelJob{
+			metadata:    metadata,
+			apiMetadata: apiMetadata,
+			// Don't generate anything but the mapper for excluded models
+			excluded: slices.Contains(g.cfg.ExcludeModels, metadata.Name),
+		}
+		jobs = append(jobs, job)
+		if job.excluded {
+			continue
+		}
+
+		createStr := fmt.Sprintf("Create%v", metadata.Name)
+		job.createApiMetadata, _ = utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
+			return m.Name == createStr
+		})
+		updateStr := fmt.Sprintf("Update%v", metadata.Name)
+		job.updateApiMetadata, _ = utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
+			return m.Name == updateStr
+		})
+
+		for _, createApiField := range job.createApiMetadata.AllFields() {
+			field := metadata.GetJsonField(createApiField.JsonName)
+			if field == nil {
+				continue
+			}
+			createApiField.MapFunc = field.MapApiFunc
+			createApiField.MapApiFunc = field.MapFunc
+		}
+		for _, updateApiField := range job.updateApiMetadata.AllFields() {
+			field := metadata.GetJsonField(updateApiField.JsonName)
+			if field == nil {
+				continue
+			}
+			updateApiField.MapFunc = field.MapApiFunc
+			updateApiField.MapApiFunc = field.MapFunc
+		}
+
+		getFilterStr := fmt.Sprintf("Get%vParams", metadata.Name)
+		job.filterMetadata, _ = utils.First(apiMetadatas, func(m *entity.GormModelMetadata) bool {
+			return m.Name == getFilterStr
+		})
+
+		for _, filterField := range job.filterMetadata.Fields {
+			modelField, err := utils.First(metadata.Fields, func(f *entity.GormModelField) bool {
+				return f.JsonName == filterField.JsonName
+			})
+			if err != nil {
+				// Not a model field, e.g. pagination
+				continue
+			}
+			filterField.MapFunc = modelField.MapFunc
+			filterField.MapApiFunc = modelField.MapApiFunc
+		}
+	}
+
+	if err := g.generateModels(jobs); err != nil {
+		return err
+	}
+
+	if err := g.generateRepositoryUtil(); err != nil {
+		return err
+	}
+
+	filteredMetadatas := []*entity.GormModelMetadata{}
+	for _, metadata := range metadatas {
+		if !slices.Contains(g.cfg.ExcludeModels, metadata.Name) {
+			filteredMetadatas = append(filteredMetadatas, metadata)
+		}
+	}
+
+	if g.cfg.GenerateServer {
+		if err := g.generateServer(filteredMetadatas); err != nil {
+			return err
+		}
+	}
+
+	if g.cfg.GenerateMain {
+		if err := g.generateMain(); err != nil {
+			return err
+		}
+	}
+
+	if err := g.generateMapperUtil(); err != nil {
+		return err
+	}
+
+	if err := g.generatePaginationUtil(); err != nil {
+		return err
+	}
+
+	if err := g.generateErrorUtil(); err != nil {
+		return err
+	}
+
+	if err := g.generateETagUtil(); err != nil {
+		return err
+	}
+
+	if err := g.generateValidationUtil(); err != nil {
+		return err
+	}
+
+	if err := g.removeStaleFiles(); err != nil {
+		return err
+	}
+
+	return g.manifest.write(g.cfg.OutputFile)
+}
+
+func (g *generator) generateOpenApiYaml(metadatas []*entity.GormModelMetadata) error {
+	if err := g.generateOpenApiEnums(metadatas); err != nil {
+		return err
+	}
+	for _, metadata := range metadatas {
+		_, err := g.generateOpenApiRoutes(metadata)
+		if err != nil {
+			return err
+		}
+	}
+	if err := g.generateOpenApiBase(metadatas); err != nil {
+		return err
+	}
+	if err := g.combineOpenApiFiles(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *generator) runCodegenTool() error {
+	g.cfg.TypesCodegen.Configuration.OutputOptions.NameNormalizer = "ToCamelCaseWithInitialisms"
+	g.cfg.ServerCodegen.Configuration.OutputOptions.NameNormalizer = "ToCamelCaseWithInitialisms"
+
+	swagger, err := newOpenApiLoader().LoadFromFile(filepath.Join(g.cfg.OutputFile, "openapi.yaml"))
+	if err != nil {
+		return err
+	}
+	code, err := codegen.Generate(swagger, g.cfg.TypesCodegen.Configuration)
+	if err != nil {
+		return err
+	}
+	if err = g.writeFile(g.cfg.TypesCodegen.OutputFile, "", []byte(code)); err != nil {
+		return err
+	}
+
+	code, err = codegen.Generate(swagger, g.cfg.ServerCodegen.Configuration)
+	if err != nil {
+		return err
+	}
+	if err = g.writeFile(g.cfg.ServerCodegen.OutputFile, "", []byte(code)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *generator) combineOpenApiFiles() error {
+	outputFp := filepath.Join(g.cfg.OutputFile, "openapi.yaml")
+	baseFp := filepath.Join(g.cfg.OutputFile, "openapi_base.gen.yaml")
+
+	// Resolve the $refs to the per-model YAML files and move them into the
+	// base document's components
+	doc, err := newOpenApiLoader().LoadFromFile(baseFp)
+	if err != nil {
+		return err
+	}
+	doc.InternalizeRefs(context.Background(), nil)
+
+	// Round-trip through JSON so the keys are sorted and the output is deterministic
+	j, err := doc.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	bundle := yaml.MapSlice{}
+	if err := yaml.Unmarshal(j, &bundle); err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	if err := g.writeFile(outputFp, "", out); err != nil {
+		return err
+	}
+
+	if g.cfg.PruneYaml {
+		entries, err := os.ReadDir(g.cfg.OutputFile)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			filename := entry.Name()
+			if strings.HasSuffix(filename, ".yaml") && filename != "openapi.yaml" {
+				fp := filepath.Join(g.cfg.OutputFile, filename)
+				if err := os.Remove(fp); err != nil {
+					return err
+				}
+				delete(g.manifest.Files, g.manifestKey(fp))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Create an OpenAPI loader that always reads files from disk.
+//
+// The default loader caches files for the lifetime of the process, which
+// would return stale files when generating more than once, e.g. with -watch.
+func newOpenApiLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile)
+	return loader
+}
+
+// Escapes a path for a JSON pointer in a $ref, like /{id}/ to ~1%7Bid%7D~1
+var pathPointerEscaper = strings.NewReplacer("/", "~1", "{", "%7B", "}", "%7D")
+
+func (g *generator) generateOpenApiBase(metadatas []*entity.GormModelMetadata) error {
+	fp := filepath.Join(g.cfg.OutputFile, "openapi_base.gen.yaml")
+
+	var doc *openapi3.T
+
+	if g.cfg.OpenApiFile != "" {
+		var err error
+		doc, err = newOpenApiLoader().LoadFromFile(g.cfg.OpenApiFile)
+		if err != nil {
+			return err
+		}
+	} else {
+		doc = &openapi3.T{
+			OpenAPI: "3.0.0",
+			Info: &openapi3.Info{
+				Version: "1.0.0",
+				Title:   "Generated API",
+			},
+			Paths: openapi3.NewPaths(),
+		}
+	}
+
+	for _, metadata := range metadatas {
+		if slices.Contains(g.cfg.ExcludeModels, metadata.Name) {
+			continue
+		}
+
+		doc.Paths.Set(fmt.Sprintf("/%v/", utils.ToHtmlCase(metadata.Name)), &openapi3.PathItem{
+			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/~1", utils.ToSnakeCase(metadata.Name)),
+		})
+		path := keyPath(metadata)
+		doc.Paths.Set(fmt.Sprintf("/%v%v", utils.ToHtmlCase(metadata.Name), path), &openapi3.PathItem{
+			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/%v", utils.ToSnakeCase(metadata.Name), pathPointerEscaper.Replace(path)),
+		})
+		doc.Paths.Set(fmt.Sprintf("/%v/batch/", utils.ToHtmlCase(metadata.Name)), &openapi3.PathItem{
+			Ref: fmt.Sprintf("./%v.gen.yaml#/paths/~1batch~1", utils.ToSnakeCase(metadata.Name)),
+		})
+		for _, association := range g.manyToManyAssociations(metadata) {
+			for _, path := range []string{association.Path, association.Path + fmt.Sprintf("{%v}/", association.AssociationKey.Param)} {
+				doc.Paths.Set(fmt.Sprintf("/%v%v", utils.ToHtmlCase(metadata.Name), path), &openapi3.PathItem{
+					Ref: fmt.Sprintf("./%v.gen.yaml#/paths/%v", utils.ToSnakeCase(metadata.Name), pathPointerEscaper.Replace(path)),
+				})
+			}
+		}
+		for _, hasMany := range g.hasManyParents(metadata) {
+			doc.Paths.Set(hasMany.Path, &openapi3.PathItem{
+				Ref: fmt.Sprintf("./%v.gen.yaml#/paths/%v", utils.ToSnakeCase(metadata.Name), pathPointerEscaper.Replace(hasMany.Path)),
+			})
+		}
+	}
+
+	if err := doc.Paths.Validate(context.Background()); err != nil {
+		return err
+	}
+
+	yamlContent, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return g.writeFile(fp, "", yamlContent)
+}
+
+func (g *generator) generateOpenApiRoutes(metadata *entity.GormModelMetadata) (string, error) {
+	fp := filepath.Join(g.cfg.OutputFile, fmt.Sprintf("%v.gen.yaml", utils.ToSnakeCase(metadata.Name)))
+
+	var b bytes.Buffer
+	g.templates.ExecuteTemplate(&b, "openapi_controller.yaml", metadata)
+
+	return fp, g.writeFile(fp, "", b.Bytes())
+}
+
+// Generate a shared schema for each enum the models use, which the model
+// schemas refer to like they refer to other models
+func (g *generator) generateOpenApiEnums(metadatas []*entity.GormModelMetadata) error {
+	modelNames := map[string]bool{}
+	for _, metadata := range metadatas {
+		modelNames[metadata.Name] = true
+	}
+
+	enums := []*entity.GormEnumMetadata{}
+	enumPkgs := map[string]string{}
+	for _, metadata := range metadatas {
+		for _, field := range metadata.AllFields() {
+			enum := field.Enum
+			if enum == nil {
+				continue
+			}
+			// Generated names don't include the package, so they must be unique
+			if other, ok := enumPkgs[enum.Name]; ok {
+				if other != enum.Package {
+					return fmt.Errorf("enum %v is defined in both %v and %v", enum.Name, other, enum.Package)
+				}
+				continue
+			}
+			if modelNames[enum.Name] {
+				return fmt.Errorf("enum %v has the same name as a model", enum.Name)
+			}
+			enumPkgs[enum.Name] = enum.Package
+			enums = append(enums, enum)
+		}
+	}
+
+	for _, enum := range enums {
+		fp := filepath.Join(g.cfg.OutputFile, fmt.Sprintf("%v.gen.yaml", utils.ToSnakeCase(enum.Name)))
+		var b bytes.Buffer
+		if err := g.templates.ExecuteTemplate(&b, "openapi_enum.yaml", enum); err != nil {
+			return err
+		}
+		if err := g.writeFile(fp, "", b.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The metadata for generating a single model's files
+type modelJob struct {
+	metadata          *entity.GormModelMetadata
+	apiMetadata       *entity.GormModelMetadata
+	createApiMetadata *entity.GormModelMetadata
+	updateApiMetadata *entity.GormModelMetadata
+	filterMetadata    *entity.GormModelMetadata
+	excluded          bool
+}
+
+// Generate the files for each model concurrently, with at most the configured
+// concurrency at once. The errors from every model are returned together.
+func (g *generator) generateModels(jobs []*modelJob) error {
+	errs := make([]error, len(jobs))
+	sem := make(chan struct{}, g.concurrency())
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, job *modelJob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := g.generateModel(job); err != nil {
+				errs[i] = fmt.Errorf("failed to generate %v: %w", job.metadata.Name, err)
+			}
+		}(i, job)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (g *generator) concurrency() int {
+	if g.cfg.Concurrency > 0 {
+		return g.cfg.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (g *generator) generateModel(job *modelJob) error {
+	t, err := g.modelTemplates(job)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	if err := g.generateMapper(t, job.metadata, job.apiMetadata); err != nil {
+		errs = append(errs, err)
+	}
+	if !job.excluded {
+		if err := g.generateRepository(t, job.metadata); err != nil {
+			errs = append(errs, err)
+		}
+		if err := g.generateController(t, job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Clone the templates with the model's conversion funcs, so the shared
+// templates aren't modified while other models are being generated
+func (g *generator) modelTemplates(job *modelJob) (*template.Template, error) {
+	t, err := g.templates.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	convertToModel := func(field *entity.GormModelField) string {
+		return convert.ConvertField(t, field, job.metadata)
+	}
+	convertToApi := func(field *entity.GormModelField) string {
+		return convert.ConvertField(t, field, job.apiMetadata)
+	}
+	convertToFilter := func(field *entity.GormModelField) string {
+		return convert.ConvertFieldNamed(t, field, job.metadata, "request.Params", "filters")
+	}
+	validate := func(field *entity.GormModelField) string {
+		return validateField(t, field, job.metadata, convert.DefaultSrc)
+	}
+	t.Funcs(template.FuncMap{
+		"ConvertToModel":  convertToModel,
+		"ConvertToApi":    convertToApi,
+		"ConvertToFilter": convertToFilter,
+		"ValidateField":   validate,
+	})
+	return t, nil
+}
+
+func (g *generator) generateController(t *template.Template, job *modelJob) error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_controller.gen.go", utils.ToSnakeCase(job.metadata.Name)))
+	version, err := findVersionField(job.metadata)
+	if err != nil {
+		return err
+	}
+
+	template := "controller.tmpl"
+	if g.cfg.ServerCodegen.Generate.EchoServer {
+		template = "echo_controller.tmpl"
+	}
+
+	return g.generateGo(
+		t,
+		fp,
+		template,
+		map[string]interface{}{
+			"package":              g.cfg.ServerCodegen.PackageName,
+			"queryPackage":         g.cfg.QueryPkg,
+			"typesPackage":         g.typesPackage,
+			"repositoryImportPath": g.repositoryPackage,
+			"model":                job.metadata,
+			"createApi":            job.createApiMetadata,
+			"updateApi":            job.updateApiMetadata,
+			"filterMetadata":       job.filterMetadata,
+			// Associations depend on the other models, so they're part of the inputs
+			"expand":     g.expandPaths(job.metadata),
+			"hasMany":    g.hasManyParents(job.metadata),
+			"manyToMany": g.manyToManyAssociations(job.metadata),
+			"version":    version,
+		},
+	)
+}
+
+func (g *generator) generateServer(metadatas []*entity.GormModelMetadata) error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "server.gen.go")
+
+	template := "server.tmpl"
+	if g.cfg.ServerCodegen.Generate.EchoServer {
+		template = "echo_server.tmpl"
+	}
+
+	return g.generateGo(
+		g.templates,
+		fp,
+		template,
+		map[string]interface{}{
+			"package":      g.cfg.ServerCodegen.PackageName,
+			"queryPackage": g.cfg.QueryPkg,
+			"typesPackage": g.typesPackage,
+			"metadatas":    metadatas,
+		},
+	)
+}
+
+func (g *generator) generateRepository(t *template.Template, metadata *entity.GormModelMetadata) error {
+	filename := fmt.Sprintf("%v_repository.gen.go", utils.ToSnakeCase(metadata.Name))
+	fp := filepath.Join(g.cfg.RepositoryConfiguration.OutputFile, filename)
+	version, err := findVersionField(metadata)
+	if err != nil {
+		return err
+	}
+	return g.generateGo(
+		t,
+		fp,
+		"repository.tmpl",
+		map[string]interface{}{
+			"pkg":      metadata.Package,
+			"queryPkg": g.cfg.QueryPkg,
+			"model":    metadata,
+			// Associations depend on the other models, so they're part of the inputs
+			"expand":     g.expandPaths(metadata),
+			"manyToMany": g.manyToManyAssociations(metadata),
+			"version":    version,
+		},
+	)
+}
+
+func (g *generator) generateRepositoryUtil() error {
+	fp := filepath.Join(g.cfg.RepositoryConfiguration.OutputFile, "repository_util.gen.go")
+	return g.generateGo(g.templates, fp, "repository_util.tmpl", map[string]any{
+		"maxExpandDepth": g.cfg.MaxExpandDepth,
+	})
+}
+
+func (g *generator) generateMapper(
+	t *template.Template,
+	metadata *entity.GormModelMetadata,
+	apiMetadata *entity.GormModelMetadata,
+) error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_mapper.gen.go", utils.ToSnakeCase(metadata.Name)))
+	apiFp := filepath.Join(g.cfg.OutputFile, "api", fmt.Sprintf("%v_api_mapper.gen.go", utils.ToSnakeCase(metadata.Name)))
+
+	errs := []error{}
+
+	err := g.generateGo(
+		t,
+		fp,
+		"model_mapper.tmpl",
+		map[string]interface{}{
+			"package":      g.cfg.ServerCodegen.PackageName,
+			"typesPackage": g.typesPackage,
+			"pkg":          metadata.Package,
+			"Name":         metadata.Name,
+			"src":          apiMetadata,
+			"dst":          metadata,
+		},
+	)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = g.generateGo(
+		t,
+		apiFp,
+		"api_mapper.tmpl",
+		map[string]interface{}{
+			"package":      g.cfg.ServerCodegen.PackageName,
+			"typesPackage": g.typesPackage,
+			"pkg":          metadata.Package,
+			"Name":         apiMetadata.Name + "Api",
+			"src":          metadata,
+			"dst":          apiMetadata,
+		},
+	)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+func (g *generator) generateMain() error {
+	fp := filepath.Join(g.cfg.OutputFile, "main.go")
+	apiImportPath := filepath.Join(g.cfg.ModuleName, g.relativePkgPath, "api")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"main.tmpl",
+		map[string]interface{}{
+			"apiImportPath": apiImportPath,
+		},
+	)
+}
+
+func (g *generator) generateMapperUtil() error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "mapper_util.gen.go")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"mapper_util.tmpl",
+		map[string]interface{}{
+			"package": g.cfg.ServerCodegen.PackageName,
+		},
+	)
+}
+
+func (g *generator) generateValidationUtil() error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "validation_util.gen.go")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"validation_util.tmpl",
+		map[string]interface{}{
+			"package":      g.cfg.ServerCodegen.PackageName,
+			"typesPackage": g.typesPackage,
+		},
+	)
+}
+
+func (g *generator) generatePaginationUtil() error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "pagination_util.gen.go")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"pagination_util.tmpl",
+		map[string]interface{}{
+			"package":         g.cfg.ServerCodegen.PackageName,
+			"defaultPageSize": g.cfg.PaginationConfiguration.DefaultPageSize,
+			"maxPageSize":     g.cfg.PaginationConfiguration.MaxPageSize,
+		},
+	)
+}
+
+func (g *generator) generateErrorUtil() error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "error_util.gen.go")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"error_util.tmpl",
+		map[string]interface{}{
+			"package":      g.cfg.ServerCodegen.PackageName,
+			"typesPackage": g.typesPackage,
+		},
+	)
+}
+
+func (g *generator) generateETagUtil() error {
+	fp := filepath.Join(g.cfg.OutputFile, "api", "etag_util.gen.go")
+	return g.generateGo(
+		g.templates,
+		fp,
+		"etag_util.tmpl",
+		map[string]interface{}{
+			"package": g.cfg.ServerCodegen.PackageName,
+		},
+	)
+}
+
+// Generate formatted Go code at the filepath with the template, unless the
+// file was already generated from the same inputs
+func (g *generator) generateGo(t *template.Template, fp string, template string, data any) error {
+	input, err := hashJSON(map[string]any{
+		"config":    g.manifest.Config,
+		"templates": hashTemplates(g.templateHashes),
+		"template":  template,
+		"data":      data,
+	})
+	if err != nil {
+		return err
+	}
+	if g.isUpToDate(fp, input) {
+		return nil
+	}
+
+	// Write the template to in-memory buffer
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	if err := t.ExecuteTemplate(w, template, data); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	code, err := g.formatGo(fp, b.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return g.writeFile(fp, input, code)
+}
+
+// Format the generated code and fix missing imports, like goimports
+func (g *generator) formatGo(fp string, src []byte) ([]byte, error) {
+	code, err := imports.Process(fp, src, &imports.Options{
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	})
+	if err != nil {
+		return nil, formatError(fp, src, err)
+	}
+
+	if g.cfg.Gofumpt {
+		code, err = gofumpt.Source(code, gofumpt.Options{ModulePath: g.cfg.ModuleName})
+		if err != nil {
+			return nil, formatError(fp, code, err)
+		}
+	}
+
+	return code, nil
+}
+
+// Add the offending line of the generated source to a formatting error
+func formatError(fp string, src []byte, err error) error {
+	line := 0
+	var errList scanner.ErrorList
+	var scanErr *scanner.Error
+	if errors.As(err, &errList) && len(errList) > 0 {
+		line = errList[0].Pos.Line
+	} else if errors.As(err, &scanErr) {
+		line = scanErr.Pos.Line
+	}
+
+	lines := strings.Split(string(src), "\n")
+	if line < 1 || line > len(lines) {
+		return fmt.Errorf("failed to format %v: %w", fp, err)
+	}
+	return fmt.Errorf("failed to format %v: %w\n%v: %v", fp, err, line, strings.TrimRight(lines[line-1], "\r"))
+}
+
+// loadTemplates loads all of our template files into a text/template. The
+// path of template is relative to the templates directory.
+func (g *generator) loadTemplates(src embed.FS, t *template.Template) error {
+	getTypesNamespace := func() string {
+		if g.typesPackage == nil {
+			return ""
+		}
+		return "types."
+	}
+
+	getDefaultPageSize := func() int {
+		return g.cfg.PaginationConfiguration.DefaultPageSize
+	}
+	getMaxPageSize := func() int {
+		return g.cfg.PaginationConfiguration.MaxPageSize
+	}
+
+	funcMap := template.FuncMap{
+		"ToLower":              strings.ToLower,
+		"ToCamelCase":          utils.ToCamelCase,
+		"ToSnakeCase":          utils.ToSnakeCase,
+		"ToHtmlCase":           utils.ToHtmlCase,
+		"ToPascalCase":         utils.ToPascalCase,
+		"ShouldExcludeField":   g.shouldExcludeField,
+		"ShouldExcludeCreate":  g.shouldExcludeCreateField,
+		"ToOpenApiType":        toOpenApiType,
+		"ToRequestOpenApiType": toRequestOpenApiType,
+		"MapToModelType":       mapToModelType,
+		"MapToApiType":         mapToApiType,
+		"IsSimpleType":         utils.IsSimpleType,
+		"IsComplexType":        utils.IsComplexType,
+		"IsNullable":           isNullable,
+		"IsRequired":           isRequired,
+		"IsHidden":             isHidden,
+		"Not":                  not,
+		"Types":                getTypesNamespace,
+		"WrapID":               wrapID,
+		"PrimaryKeys":          primaryKeys,
+		"IsCompositeKey":       isCompositeKey,
+		"HasCursor":            hasCursor,
+		"KeyPath":              keyPath,
+		"ExpandPaths":          g.expandPaths,
+		"HasManyParents":       g.hasManyParents,
+		"ManyToMany":           g.manyToManyAssociations,
+		"VersionField":         findVersionField,
+		"ShouldCreateField":    shouldCreateField,
+		"GetGormQueryType":     getGormQueryType,
+		"ToPtr":                toPtr,
+		"FromPtr":              fromPtr,
+		"QueryableFields":      queryableFields,
+		"UpdatableFields":      g.updatableFields,
+		"FilterOperators":      filterOperators,
+		"DefaultPageSize":      getDefaultPageSize,
+		"MaxPageSize":          getMaxPageSize,
+		// will be replaced per model, see modelTemplates
+		"ConvertToModel":           func() string { return "" },
+		"ConvertToApi":             func() string { return "" },
+		"ConvertToModelFromCreate": func() string { return "" },
+		"ConvertToFilter":          func() string { return "" },
+		"ValidateField":            func() string { return "" },
+	}
+
+	err := fs.WalkDir(src, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error walking directory %s: %w", path, err)
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		buf, err := src.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file '%s': %w", path, err)
+		}
+
+		templateName := strings.TrimPrefix(path, "templates/")
+		g.templateHashes[templateName] = hashBytes(buf)
+		tmpl := t.New(templateName).Funcs(funcMap)
+		_, err = tmpl.Parse(string(buf))
+		if err != nil {
+			return fmt.Errorf("parsing template '%s': %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if g.cfg.RepositoryConfiguration.Template != nil {
+		path := *g.cfg.RepositoryConfiguration.Template
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file '%s': %w", path, err)
+		}
+
+		templateName := "repository.tmpl"
+		g.templateHashes[templateName] = hashBytes(buf)
+		tmpl := t.New(templateName).Funcs(funcMap)
+		_, err = tmpl.Parse(string(buf))
+		if err != nil {
+			return fmt.Errorf("parsing template '%s': %w", path, err)
+		}
+	}
+
+	for name, path := range g.cfg.UserTemplates {
+		utpl := t.New(name)
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error loading user-provided template %q: %w", name, err)
+		}
+		g.templateHashes[name] = hashBytes(bytes)
+		txt := string(bytes)
+		_, err = utpl.Parse(txt)
+		if err != nil {
+			return fmt.Errorf("error parsing user-provided template %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Map the model field to a type for mapping to a model
+func mapToModelType(field entity.GormModelField) string {
+	result := ""
+	apiField := utils.ToPascalCase(field.Name)
+
+	switch field.Type {
+	case "uint":
+		result = fmt.Sprintf("%v: uint(obj.%v),", field.Name, apiField)
+	case "gorm.DeletedAt":
+		result = fmt.Sprintf("%v: gorm.DeletedAt{Time: *obj.%v},", field.Name, apiField)
+	default:
+		if utils.IsSimpleType(field.Type) {
+			result = fmt.Sprintf("%v: obj.%v,", field.Name, apiField)
+		} else {
+			// TODO(joeriddles): map complex types
+			result = ""
+		}
+	}
+
+	return result
+}
+
+// Map the model field to a type for mapping to an API model
+func mapToApiType(field entity.GormModelField) string {
+	result := ""
+	apiField := utils.ToPascalCase(field.Name)
+
+	switch field.Type {
+	case "uint":
+		result = fmt.Sprintf("%v: int(model.%v),", apiField, field.Name)
+	case "gorm.DeletedAt":
+		result = fmt.Sprintf("%v: func() *time.Time { if model.%v.Valid { return &model.%v.Time } else { return nil } }(),", apiField, field.Name, field.Name)
+	default:
+		result = fmt.Sprintf("%v: model.%v,", apiField, field.Name)
+	}
+
+	return result
+}
+
+// Set the API property name of each field
+func (g *generator) setJsonNames(metadatas []*entity.GormModelMetadata) {
+	for _, metadata := range metadatas {
+		for _, field := range metadata.AllFields() {
+			field.JsonName = g.jsonName(*field)
+		}
+	}
+}
+
+// Get the name of the field's API property, from its json tag or else the naming strategy
+func (g *generator) jsonName(field entity.GormModelField) string {
+	if name, _ := utils.ParseJsonTag(field.Tag); name != "" {
+		return name
+	}
+
+	switch g.cfg.NamingStrategy {
+	case config.CamelCase:
+		return utils.ToCamelCase(field.Name)
+	case config.KebabCase:
+		return utils.ToHtmlCase(field.Name)
+	default:
+		return utils.ToSnakeCase(field.Name)
+	}
+}
+
+// Whether the field is hidden from the API by a json:"-" tag
+func isHidden(field entity.GormModelField) bool {
+	return field.JsonName == "-"
+}
+
+// Whether the field should be excluded from create and update operations
+func (g *generator) shouldExcludeField(field entity.GormModelField) bool {
+	if slices.Contains(g.cfg.ExcludeFields, field.Name) || isHidden(field) {
+		return true
+	}
+
+	if field.Parent != nil {
+		if parentNamedType, ok := field.Parent.GetType().(*types.Named); ok {
+			if parentNamedType.Obj().Pkg().Name() == "gorm" && parentNamedType.Obj().Name() == "Model" {
+				return true
+			}
+		}
+	}
+
+	settings := utils.ParseGormTagSettings(field.Tag)
+	_, isAutoCreateTime := settings["AUTOCREATETIME"]
+	_, isAutoUpdateTime := settings["AUTOUPDATETIME"]
+	if _, ok := settings["ISAUTOUPDATETIME"]; ok {
+		isAutoUpdateTime = true
+	}
+	return isPrimaryKey(field) || isAutoCreateTime || isAutoUpdateTime
+}
+
+// Whether the field is left out of the create request. Unlike updates, creates
+// include the primary keys that the database or a hook doesn't generate, which
+// are string and composite keys.
+func (g *generator) shouldExcludeCreateField(model *entity.GormModelMetadata, field entity.GormModelField) bool {
+	if !g.shouldExcludeField(field) {
+		return false
+	}
+	if !isPrimaryKey(field) || isHidden(field) || slices.Contains(g.cfg.ExcludeFields, field.Name) {
+		return true
+	}
+	keys := primaryKeys(model)
+	return len(keys) == 1 && keys[0].ArgType != "string"
+}
+
+// Get the columns of the model that update operations can set
+func (g *generator) updatableFields(model *entity.GormModelMetadata) []*entity.GormModelField {
+	fields := []*entity.GormModelField{}
+	for _, field := range queryableFields(model) {
+		if !g.shouldExcludeField(*field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func toOpenApiType(field entity.GormModelField) *utils.OpenApiType {
+	openApiType := toBaseOpenApiType(field)
+	// Properties next to a $ref are ignored
+	if openApiType.Ref == nil {
+		openApiType.Description = field.Description
+	}
+	if openApiType.IsSimpleType() {
+		applyGormConstraints(openApiType, field)
+		// Keep the Go type of a field that's only optional because of omitempty
+		openApiType.SkipOptionalPointer = !isNullable(field.Type) && !isRequired(field)
+	}
+	return openApiType
+}
+
+func toBaseOpenApiType(field entity.GormModelField) *utils.OpenApiType {
+	if field.Tag != "" {
+		settings, err := utils.ParseGoalesceTagSettings(field.Tag)
+		hasOpenApiSettings := false
+		for key := range settings {
+			// Other settings, like map and version, don't change the type
+			hasOpenApiSettings = hasOpenApiSettings || strings.HasPrefix(key, "openapi_")
+		}
+		if err == nil && hasOpenApiSettings {
+			openApiType := &utils.OpenApiType{}
+
+			if typ, ok := settings["openapi_type"]; ok {
+				openApiType.Type = typ
+			}
+			if ref, ok := settings["openapi_ref"]; ok {
+				openApiType.Ref = &ref
+			}
+			if format, ok := settings["openapi_format"]; ok {
+				openApiType.Format = &format
+			}
+			if nullable, ok := settings["openapi_nullable"]; ok {
+				openApiType.Nullable = strings.ToLower(nullable) == "true"
+			}
+
+			return openApiType
+		}
+	}
+
+	if field.Enum != nil {
+		return toEnumOpenApiType(field)
+	}
+
+	return utils.ToOpenApiType(field.Type)
+}
+
+// Get the OpenAPI type of an enum field, which refers to the enum's shared
+// schema. The type is still set to the underlying type, for query parameters.
+func toEnumOpenApiType(field entity.GormModelField) *utils.OpenApiType {
+	ref := fmt.Sprintf("./%v.gen.yaml#/components/schemas/%v", utils.ToSnakeCase(field.Enum.Name), field.Enum.Name)
+	typ := "integer"
+	if field.Enum.Type == "string" {
+		typ = "string"
+	}
+	return &utils.OpenApiType{
+		Type:     typ,
+		Ref:      &ref,
+		Nullable: isNullable(field.Type),
+	}
+}
+
+// Add the constraints from the field's gorm tag to the OpenAPI type
+func applyGormConstraints(openApiType *utils.OpenApiType, field entity.GormModelField) {
+	settings := utils.ParseGormTagSettings(field.Tag)
+
+	if size, ok := settings["SIZE"]; ok && openApiType.Type == "string" && openApiType.Format == nil {
+		if maxLength, err := strconv.Atoi(size); err == nil {
+			openApiType.MaxLength = &maxLength
+		}
+	}
+
+	if value, ok := settings["DEFAULT"]; ok {
+		openApiType.Default = toOpenApiDefault(openApiType, value)
+	}
+
+	if check, ok := settings["CHECK"]; ok && (openApiType.Type == "integer" || openApiType.Type == "number") {
+		column := gormColumnName(field)
+		for _, bound := range utils.ParseGormCheckBounds(check) {
+			if bound.Column != column {
+				continue
+			}
+			value := bound.Value
+			switch bound.Operator {
+			case ">", ">=":
+				openApiType.Minimum = &value
+				openApiType.ExclusiveMinimum = bound.Operator == ">"
+			case "<", "<=":
+				openApiType.Maximum = &value
+				openApiType.ExclusiveMaximum = bound.Operator == "<"
+			}
+		}
+	}
+
+	if index, ok := settings["UNIQUEINDEX"]; ok {
+		if index == "UNIQUEINDEX" {
+			openApiType.Description = appendSentence(openApiType.Description, "Must be unique.")
+		} else {
+			name := strings.SplitN(index, ",", 2)[0]
+			openApiType.Description = appendSentence(openApiType.Description, fmt.Sprintf("Must be unique together with any other fields in the %v index.", name))
+		}
+	} else if _, ok := settings["UNIQUE"]; ok {
+		openApiType.Description = appendSentence(openApiType.Description, "Must be unique.")
+	}
+}
+
+// Append the sentence to the description, ending the description with a period first
+func appendSentence(description string, sentence string) string {
+	if description == "" {
+		return sentence
+	}
+	if !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+	return description + " " + sentence
+}
+
+// Convert a gorm default value to a JSON literal for the OpenAPI type, or ""
+// if it's an expression, like CURRENT_TIMESTAMP, that only the database knows
+func toOpenApiDefault(openApiType *utils.OpenApiType, value string) string {
+	value = strings.TrimSpace(value)
+
+	var v any
+	var err error
+	switch openApiType.Type {
+	case "string":
+		if openApiType.Format != nil {
+			return ""
+		}
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			v = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		} else if strings.Contains(value, "(") {
+			return ""
+		} else {
+			v = value
+		}
+	case "integer":
+		v, err = strconv.ParseInt(value, 10, 64)
+	case "number":
+		v, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		v, err = strconv.ParseBool(value)
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+
+	literal, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(literal)
+}
+
+// Get the name of the field's column, which gorm snake cases by default
+func gormColumnName(field entity.GormModelField) string {
+	if column, ok := utils.ParseGormTagSettings(field.Tag)["COLUMN"]; ok {
+		return column
+	}
+	return utils.ToSnakeCase(field.Name)
+}
+
+// Whether the field is required in the OpenAPI schemas, because it can't be
+// null in Go or the database, or is validated as required. Fields tagged with
+// omitempty aren't required, since their zero values are left out of the JSON.
+func isRequired(field entity.GormModelField) bool {
+	if hasValidateRule(field, "required") {
+		return true
+	}
+	if _, omitEmpty := utils.ParseJsonTag(field.Tag); omitEmpty {
+		return false
+	}
+	if !isNullable(field.Type) {
+		return true
+	}
+	_, notNull := utils.ParseGormTagSettings(field.Tag)["NOT NULL"]
+	return notNull
+}
+
+func wrapID(model *entity.GormModelMetadata) string {
+	keys := primaryKeys(model)
+	if len(keys) == 0 {
+		return "id"
+	}
+	return keys[0].QueryArg
+}
+
+// A primary key field, and how it's passed from the path to the repository
+type primaryKey struct {
+	*entity.GormModelField
+	// The path parameter, which is id unless the key is composite
+	Param string
+	// The Go name of the path parameter in the request objects, like ID
+	RequestName string
+	// The name of the repository method parameter
+	ArgName string
+	// The Go type of the repository method parameter, like int64 or uuid.UUID
+	ArgType string
+	// The parameter converted to the type of the gorm gen field, like uint(id)
+	QueryArg string
+	// If true, the path parameter uses the shared int64 id schema
+	IsInteger bool
+	// The schema of the path parameter, if it isn't an integer
+	Schema *utils.OpenApiType
+}
+
+// Get the model's primary keys, from the fields tagged with primaryKey, or the
+// ID field like gorm
+func primaryKeys(model *entity.GormModelMetadata) []*primaryKey {
+	fields := []*entity.GormModelField{}
+	for _, field := range model.AllFields() {
+		if isPrimaryKey(*field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		if field, err := utils.First(model.AllFields(), func(f *entity.GormModelField) bool {
+			return f.Name == "ID"
+		}); err == nil {
+			fields = append(fields, field)
+		}
+	}
+
+	keys := []*primaryKey{}
+	for _, field := range fields {
+		param, argName := "id", "id"
+		if len(fields) > 1 {
+			param = field.JsonName
+			argName = utils.ToCamelCase(field.Name)
+		}
+		keys = append(keys, newPrimaryKey(field, param, argName))
+	}
+	return keys
+}
+
+// Create a primary key that's passed by the path parameter and repository
+// method parameter names
+func newPrimaryKey(field *entity.GormModelField, param string, argName string) *primaryKey {
+	key := &primaryKey{
+		GormModelField: field,
+		Param:          param,
+		RequestName:    codegen.ToCamelCaseWithInitialisms(param),
+		ArgName:        argName,
+		ArgType:        field.GetGoType(),
+		QueryArg:       argName,
+		Schema:         toOpenApiType(*field),
+	}
+	if basic, ok := field.GetType().Underlying().(*types.Basic); ok {
+		if basic.Info()&types.IsInteger != 0 {
+			key.ArgType = "int64"
+			key.QueryArg = fmt.Sprintf("%v(%v)", basic.Name(), argName)
+			key.IsInteger = true
+		} else {
+			key.ArgType = basic.Name()
+		}
+	}
+	return key
+}
+
+func isPrimaryKey(field entity.GormModelField) bool {
+	settings := utils.ParseGormTagSettings(field.Tag)
+	_, isPrimaryKey := settings["PRIMARYKEY"]
+	_, isPrimaryKeySnake := settings["PRIMARY_KEY"]
+	return isPrimaryKey || isPrimaryKeySnake
+}
+
+// Whether the model's primary key is made of more than one field
+func isCompositeKey(model *entity.GormModelMetadata) bool {
+	return len(primaryKeys(model)) > 1
+}
+
+// Whether list endpoints can page by cursor, which needs a single integer key
+func hasCursor(model *entity.GormModelMetadata) bool {
+	keys := primaryKeys(model)
+	return len(keys) == 1 && keys[0].IsInteger
+}
+
+// Get the path to a single model, relative to the model's path, like /{id}/
+func keyPath(model *entity.GormModelMetadata) string {
+	path := "/"
+	for _, key := range primaryKeys(model) {
+		path += fmt.Sprintf("{%v}/", key.Param)
+	}
+	return path
+}
+
+// The field that versions a model for ETags and If-Match
+type versionField struct {
+	*entity.GormModelField
+	// If true, the field is a time.Time, like UpdatedAt. Otherwise, it's an integer.
+	IsTime bool
+}
+
+// Get the field compared with If-Match to detect concurrent updates, which is
+// the field tagged with goalesce:"version", or UpdatedAt. Returns nil if the
+// model has neither. gorm must change the field on every update, so a tagged
+// field must be UpdatedAt or have an autoUpdateTime tag.
+func findVersionField(model *entity.GormModelMetadata) (*versionField, error) {
+	for _, field := range model.AllFields() {
+		settings, err := utils.ParseGoalesceTagSettings(field.Tag)
+		if _, ok := settings["version"]; err != nil || !ok {
+			continue
+		}
+
+		version := newVersionField(field)
+		gormSettings := utils.ParseGormTagSettings(field.Tag)
+		_, isAutoUpdateTime := gormSettings["AUTOUPDATETIME"]
+		if version == nil || !(field.Name == "UpdatedAt" || isAutoUpdateTime) {
+			return nil, fmt.Errorf("version field %v.%v must be a time.Time or integer with an autoUpdateTime tag", model.Name, field.Name)
+		}
+		return version, nil
+	}
+
+	field, err := utils.First(model.AllFields(), func(f *entity.GormModelField) bool {
+		return f.Name == "UpdatedAt"
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return newVersionField(field), nil
+}
+
+// Create a version field, or return nil if the field's type can't be a version
+func newVersionField(field *entity.GormModelField) *versionField {
+	switch t := field.GetType().(type) {
+	case *types.Named:
+		if t.Obj().Pkg() != nil && t.Obj().Pkg().Path() == "time" && t.Obj().Name() == "Time" {
+			return &versionField{GormModelField: field, IsTime: true}
+		}
+	}
+	if basic, ok := field.GetType().Underlying().(*types.Basic); ok && basic.Info()&types.IsInteger != 0 {
+		return &versionField{GormModelField: field}
+	}
+	return nil
+}
+
+// An association that the expand query parameter can preload
+type expandPath struct {
+	// The dotted API property names, like vehicle_model.manufacturer
+	Path string
+	// The dotted Go field names that gorm preloads, like VehicleModel.Manufacturer
+	Relation string
+}
+
+// Get the associations of the model that can be expanded, including nested
+// associations up to the configured depth
+func (g *generator) expandPaths(model *entity.GormModelMetadata) []*expandPath {
+	return g.appendExpandPaths([]*expandPath{}, model, nil, g.cfg.MaxExpandDepth)
+}
+
+func (g *generator) appendExpandPaths(paths []*expandPath, model *entity.GormModelMetadata, parent *expandPath, depth int) []*expandPath {
+	if depth <= 0 {
+		return paths
+	}
+	for _, field := range model.AllFields() {
+		if isHidden(*field) {
+			continue
+		}
+		association := g.associatedModel(*field)
+		if association == nil {
+			continue
+		}
+		path := &expandPath{Path: field.JsonName, Relation: field.Name}
+		if parent != nil {
+			path.Path = parent.Path + "." + path.Path
+			path.Relation = parent.Relation + "." + path.Relation
+		}
+		paths = append(paths, path)
+		paths = g.appendExpandPaths(paths, association, path, depth-1)
+	}
+	return paths
+}
+
+// Get the parsed model that the field belongs to or has, like Manufacturer
+// for a Manufacturer or []*Manufacturer field, or nil if it isn't an association
+func (g *generator) associatedModel(field entity.GormModelField) *entity.GormModelMetadata {
+	t := field.GetType()
+	if t == nil {
+		return nil
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if slice, ok := t.(*types.Slice); ok {
+		t = slice.Elem()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	for _, model := range g.models {
+		if model.Name == named.Obj().Name() && model.Package == named.Obj().Pkg().Path() {
+			return model
+		}
+	}
+	return nil
+}
+
+// A has-many association of a parent model, like Manufacturer.Vehicles, which
+// gets routes to list and create the parent's children
+type hasMany struct {
+	// The association field of the parent model
+	*entity.GormModelField
+	Parent *entity.GormModelMetadata
+	// The parent's primary key
+	Key *primaryKey
+	// The child's foreign key to the parent, like ManufacturerID
+	ForeignKey *entity.GormModelField
+	// The basic type of the foreign key, like uint
+	ForeignKeyType string
+	// If true, the foreign key is a pointer
+	IsForeignKeyPointer bool
+	// The route to the parent's children, like /manufacturer/{id}/vehicles/
+	Path string
+	// The operation name, like ManufacturerVehicles
+	OperationName string
+	// The schema of the create route's request body, like CreateVehicleForManufacturer
+	CreateSchema string
+	// The JSON names of the child's fields that are set from the parent in the
+	// path, which are the foreign key and any association to the parent
+	parentFields []string
+}
+
+// Whether the child's field is set from the parent in the path, so it isn't in
+// the create route's request body
+func (r *hasMany) IsParentField(jsonName string) bool {
+	return slices.Contains(r.parentFields, jsonName)
+}
+
+// Get the has-many associations of other models that have the model as their
+// children. The parent must have a single key and the child must be able to
+// filter by its foreign key.
+func (g *generator) hasManyParents(child *entity.GormModelMetadata) []*hasMany {
+	relations := []*hasMany{}
+	for _, parent := range g.models {
+		if slices.Contains(g.cfg.ExcludeModels, parent.Name) {
+			continue
+		}
+		keys := primaryKeys(parent)
+		if len(keys) != 1 {
+			continue
+		}
+		for _, field := range parent.AllFields() {
+			if isHidden(*field) || g.associatedModel(*field) != child {
+				continue
+			}
+			if _, ok := field.GetType().Underlying().(*types.Slice); !ok {
+				continue
+			}
+			settings := utils.ParseGormTagSettings(field.Tag)
+			if _, ok := settings["MANY2MANY"]; ok {
+				continue
+			}
+			foreignKeyName := parent.Name + keys[0].Name
+			if name, ok := settings["FOREIGNKEY"]; ok {
+				foreignKeyName = name
+			}
+			foreignKey, err := utils.First(queryableFields(child), func(f *entity.GormModelField) bool {
+				return f.Name == foreignKeyName
+			})
+			if err != nil {
+				continue
+			}
+
+			relation := &hasMany{
+				GormModelField: field,
+				Parent:         parent,
+				Key:            keys[0],
+				ForeignKey:     foreignKey,
+				Path:           fmt.Sprintf("/%v%v%v/", utils.ToHtmlCase(parent.Name), keyPath(parent), utils.ToHtmlCase(field.Name)),
+				OperationName:  parent.Name + field.Name,
+				CreateSchema:   fmt.Sprintf("Create%vFor%v", child.Name, parent.Name),
+				parentFields:   []string{foreignKey.JsonName},
+			}
+			// Models can be named like a create schema, like VehicleForSale, and
+			// a parent can have more than one association with the child
+			clashes := slices.ContainsFunc(g.models, func(m *entity.GormModelMetadata) bool {
+				return "Create"+m.Name == relation.CreateSchema
+			}) || slices.ContainsFunc(relations, func(r *hasMany) bool {
+				return r.CreateSchema == relation.CreateSchema
+			})
+			if clashes {
+				relation.CreateSchema += field.Name
+			}
+			for _, childField := range child.AllFields() {
+				if child == parent || g.associatedModel(*childField) != parent {
+					continue
+				}
+				if _, ok := childField.GetType().Underlying().(*types.Slice); !ok {
+					relation.parentFields = append(relation.parentFields, childField.JsonName)
+				}
+			}
+			t := foreignKey.GetType()
+			if ptr, ok := t.(*types.Pointer); ok {
+				relation.IsForeignKeyPointer = true
+				t = ptr.Elem()
+			}
+			basic, ok := t.Underlying().(*types.Basic)
+			if !ok {
+				continue
+			}
+			relation.ForeignKeyType = basic.Name()
+			relations = append(relations, relation)
+		}
+	}
+	return relations
+}
+
+// A many-to-many association of a model, like Part.Models, which gets routes
+// to list, add, and remove the associated models
+type manyToMany struct {
+	// The association field of the model
+	*entity.GormModelField
+	// The associated model
+	Association *entity.GormModelMetadata
+	// The model's primary key
+	Key *primaryKey
+	// The associated model's primary key, whose path parameter is named after
+	// the association, like model_id
+	AssociationKey *primaryKey
+	// The route to the associated models, relative to the model's path, like /{id}/models/
+	Path string
+	// The singular name of the association, like Model
+	SingularName string
+}
+
+// Get the many-to-many associations of the model. Both models must have a
+// single key and be in the same package.
+func (g *generator) manyToManyAssociations(model *entity.GormModelMetadata) []*manyToMany {
+	associations := []*manyToMany{}
+	keys := primaryKeys(model)
+	if len(keys) != 1 {
+		return associations
+	}
+	for _, field := range model.AllFields() {
+		if isHidden(*field) {
+			continue
+		}
+		if _, ok := utils.ParseGormTagSettings(field.Tag)["MANY2MANY"]; !ok {
+			continue
+		}
+		association := g.associatedModel(*field)
+		if association == nil || association.Package != model.Package {
+			continue
+		}
+		associationKeys := primaryKeys(association)
+		if len(associationKeys) != 1 {
+			continue
+		}
+
+		singular := utils.ToSingular(field.Name)
+		param := g.jsonName(entity.GormModelField{Name: singular + "ID"})
+		associations = append(associations, &manyToMany{
+			GormModelField: field,
+			Association:    association,
+			Key:            keys[0],
+			AssociationKey: newPrimaryKey(associationKeys[0].GormModelField, param, utils.ToCamelCase(singular)+"ID"),
+			Path:           fmt.Sprintf("%v%v/", keyPath(model), utils.ToHtmlCase(field.Name)),
+			SingularName:   singular,
+		})
+	}
+	return associations
+}
+
+// Get the fields of the model that list endpoints can be filtered and sorted by
+func queryableFields(model *entity.GormModelMetadata) []*entity.GormModelField {
+	fields := []*entity.GormModelField{}
+	for _, field := range model.AllFields() {
+		if isHidden(*field) {
+			continue
+		}
+		if toOpenApiType(*field).IsSimpleType() || field.Enum != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// A comparison operator for list endpoint filters, like the gte in cost[gte]
+type filterOperator struct {
+	Name        string // The query parameter suffix
+	GoName      string // The filter struct field suffix
+	Method      string // The gorm gen field method
+	Description string
+}
+
+var (
+	neOperator     = filterOperator{"ne", "Ne", "Neq", "is not equal to the value"}
+	gtOperator     = filterOperator{"gt", "Gt", "Gt", "is greater than the value"}
+	gteOperator    = filterOperator{"gte", "Gte", "Gte", "is greater than or equal to the value"}
+	ltOperator     = filterOperator{"lt", "Lt", "Lt", "is less than the value"}
+	lteOperator    = filterOperator{"lte", "Lte", "Lte", "is less than or equal to the value"}
+	likeOperator   = filterOperator{"like", "Like", "Like", "matches the SQL LIKE pattern, where % matches any characters"}
+	inOperator     = filterOperator{"in", "In", "In", "is one of the values. Repeat the parameter to pass multiple values"}
+	isNullOperator = filterOperator{"is_null", "IsNull", "IsNull", "is null, or is not null if false"}
+)
+
+// Get the operators a field can be filtered by, in addition to equality.
+//
+// The operators follow the field types gorm gen generates: strings support
+// pattern matching, numbers and times support comparisons, and types gen
+// can't compare (like bools or custom scanners) only support equality.
+func filterOperators(field *entity.GormModelField) []filterOperator {
+	operators := []filterOperator{}
+
+	t := field.GetType()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if t != nil && !isScanValuer(t) {
+		if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+			operators = append(operators, gtOperator, gteOperator, ltOperator, lteOperator)
+		} else if basic, ok := t.Underlying().(*types.Basic); ok {
+			if basic.Info()&types.IsString != 0 {
+				operators = append(operators, neOperator, likeOperator, inOperator)
+			} else if basic.Info()&types.IsNumeric != 0 {
+				operators = append(operators, neOperator, gtOperator, gteOperator, ltOperator, lteOperator, inOperator)
+			}
+		}
+	}
+
+	if isNullable(field.Type) {
+		operators = append(operators, isNullOperator)
+	}
+	return operators
+}
+
+// gorm gen uses the generic field.Field for types implementing sql.Scanner and
+// driver.Valuer, which only supports equality
+func isScanValuer(t types.Type) bool {
+	methods := types.NewMethodSet(types.NewPointer(t))
+	return methods.Lookup(nil, "Scan") != nil && methods.Lookup(nil, "Value") != nil
+}
+
+// Get the type of the field's gorm gen field, which is the underlying type for enums
+func getGormQueryType(field *entity.GormModelField) string {
+	if field.Enum != nil {
+		if isNullable(field.Type) {
+			return "*" + field.Enum.Type
+		}
+		return field.Enum.Type
+	}
+
+	t := field.GetGoType()
+	if t == "time.Duration" {
+		t = "int64"
+	}
+	return t
+}
+
+func toPtr(t string) string {
+	if !strings.HasPrefix(t, "*") {
+		t = "*" + t
+	}
+	return t
+}
+
+func fromPtr(t string) string {
+	return strings.TrimPrefix(t, "*")
+}
+
+func not(v bool) bool {
+	return !v
+}
+
+func isNullable(t string) bool {
+	openApiType := utils.ToOpenApiType(t)
+	return openApiType.Nullable
+}
+
+func shouldCreateField(f entity.GormModelField) bool {
+	return !strings.HasPrefix(f.Type, "*") && !strings.HasPrefix(f.Type, "[]")
+
+	// typ := f.GetType()
+
+	// if f.MapFunc != nil {
+	// 	mapFuncSig := f.GetMapFuncSignature()
+	// 	resType := mapFuncSig.Results().At(0)
+	// 	typ = resType.Type()
+	// }
+
+	// switch t := typ.(type) {
+	// case *types.Basic:
+	// 	return true
+	// case *types.Pointer:
+	// 	switch t.Elem().(type) {
+	// 	case *types.Basic:
+	// 		return true
+	// 	default:
+	// 		return false
+	// 	}
+	// case *types.Array, *types.Slice, *types.Map, *types.Chan, *types.Struct, *types.Tuple, *types.Signature, *types.Named, *types.Interface:
+	// 	return false
+	// default:
+	// 	panic(fmt.Sprintf("impossible: %T", t))
+	// }
+}
+
+func createDirs(paths ...string) error {
+	for _, path := range paths {
+		if err := os.MkdirAll(path, os.ModePerm); err != nil {
+			if err.Error() != "mkdir generated: file exists" {
+				return fmt.Errorf("could not create path %v: %v", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func filepathToPackage(path string) string {
+	return strings.ReplaceAll(path, "\\", "/")
+}
@@ -0,0 +1,269 @@
+package generate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+
+	"github.com/joeriddles/goalesce/pkg/config"
+	"github.com/joeriddles/goalesce/pkg/entity"
+)
+
+// The manifest of generated files, written to the output directory
+const manifestFilename = "goalesce.manifest.json"
+
+var (
+	// The header oapi-codegen writes, which has the version goalesce was built as
+	versionHeaderRegexp = regexp.MustCompile(`(?m)^// Code generated by .+ version .+ DO NOT EDIT\.(\r?)$`)
+	// The manifest's version, which MarshalIndent always writes on its own line
+	manifestVersionRegexp = regexp.MustCompile(`(?m)^  "version": ".*",(\r?)$`)
+)
+
+// The inputs and outputs of a generation run, used to skip re-rendering files
+// whose inputs have not changed since the last run
+type manifest struct {
+	// The goalesce version that generated the files
+	Version string `json:"version"`
+	// Hash of the config, excluding paths
+	Config string `json:"config"`
+	// Hash of each template by name
+	Templates map[string]string `json:"templates"`
+	// Hash of each model's parsed metadata by name
+	Models map[string]string `json:"models"`
+	// Generated files by path, relative to the output directory
+	Files map[string]manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	// Hash of the template, config, and data the file was rendered from
+	Input string `json:"input,omitempty"`
+	// Hash of the file contents
+	Output string `json:"output"`
+}
+
+func newManifest(templates map[string]string) *manifest {
+	return &manifest{
+		Version:   generatorVersion(),
+		Templates: templates,
+		Models:    map[string]string{},
+		Files:     map[string]manifestFile{},
+	}
+}
+
+// Read the manifest from the output directory, or nil if there isn't one
+func readManifest(dir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Get the paths of the files generated by the last run into the output
+// directory, or nil if there's no manifest
+func GeneratedFiles(dir string) ([]string, error) {
+	m, err := readManifest(dir)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	files := []string{}
+	for key := range m.Files {
+		files = append(files, filepath.Join(dir, filepath.FromSlash(key)))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (m *manifest) write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFilename), append(data, '\n'), 0o644)
+}
+
+// Hash the config, ignoring where the inputs and outputs are so the hash is
+// the same for a copy of the module. Template contents are hashed separately.
+func hashConfig(cfg *config.Config) (string, error) {
+	c := *cfg
+	c.InputFolderPaths = nil
+	c.OutputFile = ""
+	c.OpenApiFile = ""
+	c.UserTemplates = nil
+	// Doesn't affect the output
+	c.Concurrency = 0
+
+	if cfg.RepositoryConfiguration != nil {
+		repositoryCfg := *cfg.RepositoryConfiguration
+		repositoryCfg.OutputFile = ""
+		repositoryCfg.Template = nil
+		c.RepositoryConfiguration = &repositoryCfg
+	}
+	if cfg.TypesCodegen != nil {
+		typesCodegen := *cfg.TypesCodegen
+		typesCodegen.OutputFile = ""
+		c.TypesCodegen = &typesCodegen
+	}
+	if cfg.ServerCodegen != nil {
+		serverCodegen := *cfg.ServerCodegen
+		serverCodegen.OutputFile = ""
+		c.ServerCodegen = &serverCodegen
+	}
+
+	return hashJSON(c)
+}
+
+func hashModels(metadatas []*entity.GormModelMetadata) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, metadata := range metadatas {
+		hash, err := hashJSON(metadata)
+		if err != nil {
+			return nil, err
+		}
+		hashes[metadata.Name] = hash
+	}
+	return hashes, nil
+}
+
+// Hash all of the templates together, since templates can call each other
+func hashTemplates(templates map[string]string) string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(templates[name]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return hashBytes(data), nil
+}
+
+// Hash a generated file, ignoring the version of goalesce in its header
+func hashOutput(data []byte) string {
+	return hashBytes(NormalizeVersion("", data))
+}
+
+// Remove the version of goalesce from a generated file or manifest, since it
+// depends on how goalesce was built and not on what it generated
+func NormalizeVersion(fp string, data []byte) []byte {
+	if filepath.Base(fp) == manifestFilename {
+		return manifestVersionRegexp.ReplaceAll(data, []byte(`  "version": "",$1`))
+	}
+	return versionHeaderRegexp.ReplaceAll(data, []byte("// Code generated by goalesce DO NOT EDIT.$1"))
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get the module version of goalesce, so upgrading it regenerates everything.
+// VCS build settings aren't included, so rebuilding the same version doesn't
+// change the manifest.
+func generatorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	version := info.Main.Version
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/joeriddles/goalesce" {
+			version = dep.Version
+		}
+	}
+	return version
+}
+
+// Get the manifest key for a generated file
+func (g *generator) manifestKey(fp string) string {
+	rel, err := filepath.Rel(g.cfg.OutputFile, fp)
+	if err != nil {
+		return filepath.ToSlash(fp)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Whether the file was generated from the same inputs by the last run and
+// hasn't been modified since. If so, it's kept in the new manifest.
+func (g *generator) isUpToDate(fp string, input string) bool {
+	if g.previous == nil {
+		return false
+	}
+	key := g.manifestKey(fp)
+	file, ok := g.previous.Files[key]
+	if !ok || file.Input != input {
+		return false
+	}
+	data, err := os.ReadFile(fp)
+	if err != nil || hashOutput(data) != file.Output {
+		return false
+	}
+	g.mu.Lock()
+	g.manifest.Files[key] = file
+	g.mu.Unlock()
+	return true
+}
+
+// Write the generated file, unless it already has the same contents, and
+// record it in the manifest
+func (g *generator) writeFile(fp string, input string, data []byte) error {
+	g.mu.Lock()
+	g.manifest.Files[g.manifestKey(fp)] = manifestFile{
+		Input:  input,
+		Output: hashOutput(data),
+	}
+	g.mu.Unlock()
+
+	existing, err := os.ReadFile(fp)
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return os.WriteFile(fp, data, 0o644)
+}
+
+// Remove the files generated by the last run that weren't generated by this one
+func (g *generator) removeStaleFiles() error {
+	if g.previous == nil {
+		return nil
+	}
+	for key := range g.previous.Files {
+		if _, ok := g.manifest.Files[key]; ok {
+			continue
+		}
+		fp := filepath.Join(g.cfg.OutputFile, filepath.FromSlash(key))
+		g.logger.Printf("removing %v", fp)
+		if err := os.Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/joeriddles/goalesce/pkg/entity"
+	"github.com/joeriddles/goalesce/pkg/utils"
+)
+
+// Patterns for the validate rules that check a string's characters
+var validatePatterns = map[string]string{
+	"alpha":    `^[a-zA-Z]+$`,
+	"alphanum": `^[a-zA-Z0-9]+$`,
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+}
+
+// Formats for the validate rules that check a string's format
+var validateFormats = map[string]string{
+	"email": "email",
+	"url":   "uri",
+	"uri":   "uri",
+	"uuid":  "uuid",
+}
+
+// Get the OpenAPI type of a field in a create or update request, which also
+// has the constraints from the field's validate tag
+func toRequestOpenApiType(field entity.GormModelField) *utils.OpenApiType {
+	openApiType := toOpenApiType(field)
+	if openApiType.IsSimpleType() {
+		applyValidateConstraints(openApiType, field)
+	}
+	return openApiType
+}
+
+// Add the constraints from the field's validate tag to the OpenAPI type
+func applyValidateConstraints(openApiType *utils.OpenApiType, field entity.GormModelField) {
+	isString := openApiType.Type == "string" && openApiType.Format == nil
+	isNumber := openApiType.Type == "integer" || openApiType.Type == "number"
+
+	for _, rule := range utils.ParseValidateTagRules(field.Tag) {
+		if isString {
+			n, err := strconv.Atoi(rule.Param)
+			switch {
+			case rule.Name == "min" || rule.Name == "gte":
+				if err == nil {
+					openApiType.MinLength = &n
+				}
+			case rule.Name == "gt":
+				if err == nil {
+					n++
+					openApiType.MinLength = &n
+				}
+			case rule.Name == "max" || rule.Name == "lte":
+				if err == nil {
+					openApiType.MaxLength = &n
+				}
+			case rule.Name == "lt":
+				if err == nil {
+					n--
+					openApiType.MaxLength = &n
+				}
+			case rule.Name == "len":
+				if err == nil {
+					openApiType.MinLength = &n
+					openApiType.MaxLength = &n
+				}
+			case rule.Name == "oneof":
+				openApiType.Enum = []string{}
+				for _, value := range utils.SplitOneOf(rule.Param) {
+					literal, _ := json.Marshal(value)
+					openApiType.Enum = append(openApiType.Enum, string(literal))
+				}
+			case validatePatterns[rule.Name] != "":
+				openApiType.Pattern = validatePatterns[rule.Name]
+			case validateFormats[rule.Name] != "":
+				format := validateFormats[rule.Name]
+				openApiType.Format = &format
+			}
+		} else if isNumber {
+			n, err := strconv.ParseFloat(rule.Param, 64)
+			switch rule.Name {
+			case "min", "gte", "gt":
+				if err == nil {
+					openApiType.Minimum = &n
+					openApiType.ExclusiveMinimum = rule.Name == "gt"
+				}
+			case "max", "lte", "lt":
+				if err == nil {
+					openApiType.Maximum = &n
+					openApiType.ExclusiveMaximum = rule.Name == "lt"
+				}
+			case "oneof":
+				openApiType.Enum = []string{}
+				for _, value := range utils.SplitOneOf(rule.Param) {
+					if _, err := strconv.ParseFloat(value, 64); err == nil {
+						openApiType.Enum = append(openApiType.Enum, value)
+					}
+				}
+			}
+		}
+	}
+
+	// Keep the Go type of the field, instead of oapi-codegen generating an
+	// enum type or a type for the format, so it still converts to the model
+	if openApiType.Enum != nil || (openApiType.Format != nil && *openApiType.Format != "uri") {
+		openApiType.GoType = basicTypeName(field.GetType())
+	}
+}
+
+// Get the name of the basic type underlying t, like string, or "" if there isn't one
+func basicTypeName(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	if basic, ok := t.Underlying().(*types.Basic); ok {
+		return basic.Name()
+	}
+	return ""
+}
+
+// Whether the field's validate tag has the rule
+func hasValidateRule(field entity.GormModelField, name string) bool {
+	for _, rule := range utils.ParseValidateTagRules(field.Tag) {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// A check generated from a rule in a field's validate tag
+type validateCheck struct {
+	// The Go condition for when the value is invalid
+	Cond    string
+	Message string
+}
+
+// Generate the code that validates the request field against the rules in the
+// model field's validate tag, for the validate_field.tmpl template
+func validateField(t *template.Template, field *entity.GormModelField, model *entity.GormModelMetadata, src string) string {
+	if field.MapFunc != nil || field.MapApiFunc != nil {
+		return ""
+	}
+	modelField := model.GetJsonField(field.JsonName)
+	if modelField == nil {
+		return ""
+	}
+	rules := utils.ParseValidateTagRules(modelField.Tag)
+	if len(rules) == 0 && modelField.Enum == nil {
+		return ""
+	}
+
+	typ := field.GetType()
+	ptr, isPointer := typ.(*types.Pointer)
+	if isPointer {
+		typ = ptr.Elem()
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return ""
+	}
+	isString := basic.Info()&types.IsString != 0
+	isNumber := basic.Info()&types.IsNumeric != 0
+
+	value := fmt.Sprintf("%v.%v", src, field.Name)
+	zero := "0"
+	if isString {
+		zero = `""`
+	} else if !isNumber {
+		zero = "false"
+	}
+
+	required := false
+	omitEmpty := false
+	checks := []validateCheck{}
+	valueOf := value
+	if isPointer {
+		valueOf = "*" + value
+	}
+
+	for _, rule := range rules {
+		switch rule.Name {
+		case "required":
+			required = true
+			continue
+		case "omitempty":
+			omitEmpty = true
+			continue
+		}
+
+		var check *validateCheck
+		if isString {
+			check = validateStringCheck(rule, valueOf)
+		} else if isNumber {
+			check = validateNumberCheck(rule, valueOf)
+		}
+		if check != nil {
+			checks = append(checks, *check)
+		}
+	}
+
+	// Enums are always checked, since oapi-codegen doesn't check them when decoding
+	if modelField.Enum != nil {
+		checks = append(checks, validateEnumCheck(modelField.Enum, valueOf))
+	}
+
+	if !required && len(checks) == 0 {
+		return ""
+	}
+
+	// Only check values that were sent
+	guard := ""
+	if isPointer {
+		guard = fmt.Sprintf("%v != nil", value)
+	} else if omitEmpty {
+		guard = fmt.Sprintf("%v != %v", value, zero)
+	}
+	requiredCond := ""
+	if required {
+		requiredCond = fmt.Sprintf("%v == %v", value, zero)
+		if isPointer {
+			requiredCond = fmt.Sprintf("%v == nil", value)
+		}
+	}
+
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	if err := t.ExecuteTemplate(w, "validate_field.tmpl", map[string]any{
+		"name":     field.JsonName,
+		"required": requiredCond,
+		"guard":    guard,
+		"checks":   checks,
+	}); err != nil {
+		return err.Error()
+	}
+	if err := w.Flush(); err != nil {
+		return err.Error()
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func validateStringCheck(rule utils.ValidateRule, value string) *validateCheck {
+	length := fmt.Sprintf("utf8.RuneCountInString(%v)", value)
+	n, err := strconv.Atoi(rule.Param)
+	isInt := err == nil
+
+	switch {
+	case (rule.Name == "min" || rule.Name == "gte") && isInt:
+		return &validateCheck{fmt.Sprintf("%v < %v", length, n), fmt.Sprintf("must be at least %v characters", n)}
+	case rule.Name == "gt" && isInt:
+		return &validateCheck{fmt.Sprintf("%v <= %v", length, n), fmt.Sprintf("must be more than %v characters", n)}
+	case (rule.Name == "max" || rule.Name == "lte") && isInt:
+		return &validateCheck{fmt.Sprintf("%v > %v", length, n), fmt.Sprintf("must be at most %v characters", n)}
+	case rule.Name == "lt" && isInt:
+		return &validateCheck{fmt.Sprintf("%v >= %v", length, n), fmt.Sprintf("must be less than %v characters", n)}
+	case rule.Name == "len" && isInt:
+		return &validateCheck{fmt.Sprintf("%v != %v", length, n), fmt.Sprintf("must be exactly %v characters", n)}
+	case rule.Name == "oneof":
+		values := utils.SplitOneOf(rule.Param)
+		quoted := utils.Map(values, strconv.Quote)
+		return &validateCheck{
+			fmt.Sprintf("!oneOf(%v, %v)", value, strings.Join(quoted, ", ")),
+			fmt.Sprintf("must be one of %v", strings.Join(values, ", ")),
+		}
+	case rule.Name == "email":
+		return &validateCheck{fmt.Sprintf("!isEmail(%v)", value), "must be a valid email address"}
+	case rule.Name == "url" || rule.Name == "uri":
+		return &validateCheck{fmt.Sprintf("!isURL(%v)", value), "must be a valid URL"}
+	case rule.Name == "uuid":
+		return &validateCheck{fmt.Sprintf("!uuidRegex.MatchString(%v)", value), "must be a valid UUID"}
+	case rule.Name == "alpha":
+		return &validateCheck{fmt.Sprintf("!alphaRegex.MatchString(%v)", value), "must only contain letters"}
+	case rule.Name == "alphanum":
+		return &validateCheck{fmt.Sprintf("!alphanumRegex.MatchString(%v)", value), "must only contain letters and numbers"}
+	case rule.Name == "numeric":
+		return &validateCheck{fmt.Sprintf("!numericRegex.MatchString(%v)", value), "must be a number"}
+	}
+	return nil
+}
+
+func validateNumberCheck(rule utils.ValidateRule, value string) *validateCheck {
+	if rule.Name == "oneof" {
+		values := []string{}
+		for _, v := range utils.SplitOneOf(rule.Param) {
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				values = append(values, v)
+			}
+		}
+		return &validateCheck{
+			fmt.Sprintf("!oneOf(%v, %v)", value, strings.Join(values, ", ")),
+			fmt.Sprintf("must be one of %v", strings.Join(values, ", ")),
+		}
+	}
+
+	if _, err := strconv.ParseFloat(rule.Param, 64); err != nil {
+		return nil
+	}
+	n := rule.Param
+
+	switch rule.Name {
+	case "min", "gte":
+		return &validateCheck{fmt.Sprintf("%v < %v", value, n), fmt.Sprintf("must be at least %v", n)}
+	case "gt":
+		return &validateCheck{fmt.Sprintf("%v <= %v", value, n), fmt.Sprintf("must be greater than %v", n)}
+	case "max", "lte":
+		return &validateCheck{fmt.Sprintf("%v > %v", value, n), fmt.Sprintf("must be at most %v", n)}
+	case "lt":
+		return &validateCheck{fmt.Sprintf("%v >= %v", value, n), fmt.Sprintf("must be less than %v", n)}
+	}
+	return nil
+}
+
+func validateEnumCheck(enum *entity.GormEnumMetadata, value string) validateCheck {
+	literals := []string{}
+	values := []string{}
+	for _, enumValue := range enum.Values {
+		literals = append(literals, enumValue.Value)
+		var v any
+		if err := json.Unmarshal([]byte(enumValue.Value), &v); err != nil {
+			v = enumValue.Value
+		}
+		values = append(values, fmt.Sprint(v))
+	}
+	return validateCheck{
+		fmt.Sprintf("!oneOf(%v, %v)", value, strings.Join(literals, ", ")),
+		fmt.Sprintf("must be one of %v", strings.Join(values, ", ")),
+	}
+}
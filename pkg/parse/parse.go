@@ -1,9 +1,16 @@
 package parse
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 	"log"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/joeriddles/goalesce/pkg/config"
@@ -13,7 +20,7 @@ import (
 )
 
 type Parser interface {
-	Parse(filepath string) ([]*entity.GormModelMetadata, error)
+	Parse(paths ...string) ([]*entity.GormModelMetadata, error)
 }
 
 const LoadAll = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedDeps | packages.NeedExportFile | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax
@@ -21,7 +28,9 @@ const LoadAll = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGo
 type parser struct {
 	cfg    *config.Config
 	logger *log.Logger
-	pkg    *packages.Package
+
+	// Doc comments by the position of the type or field they document
+	docs map[token.Pos]string
 }
 
 func NewParser(
@@ -34,68 +43,111 @@ func NewParser(
 	}
 }
 
-// Given a the filepath to a package with GORM models, parse all the models
-func (p *parser) Parse(pkgStr string) ([]*entity.GormModelMetadata, error) {
+// Given the filepaths to packages with GORM models, parse all the models.
+// Paths may be package patterns, like ./model/..., to include subpackages.
+//
+// If a filepath is a Go file, only that file is parsed.
+func (p *parser) Parse(paths ...string) ([]*entity.GormModelMetadata, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no packages to parse")
+	}
+
+	// The packages are loaded from the module of the first path
+	dir := config.PackageDir(paths[0])
+	if filepath.Ext(dir) == ".go" {
+		dir = filepath.Dir(dir)
+	}
 	conf := &packages.Config{
 		Mode: LoadAll,
-		Dir:  pkgStr,
+		Dir:  dir,
 	}
-	pkgs, err := packages.Load(conf, pkgStr)
+	pkgs, err := packages.Load(conf, paths...)
 	if err != nil {
 		return nil, err
 	}
 
 	if packages.PrintErrors(pkgs) > 0 {
-		return nil, fmt.Errorf("pkg %v had errors", pkgStr)
+		return nil, fmt.Errorf("pkg %v had errors", strings.Join(paths, ", "))
 	}
 
+	p.docs = map[token.Pos]string{}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if p.isModulePackage(pkg.PkgPath) {
+			p.parseDocs(pkg.Syntax)
+		}
+	})
+
+	// Sort so the metadata is in the same order no matter how the packages were matched
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].PkgPath < pkgs[j].PkgPath
+	})
+
 	metadatas := []*entity.GormModelMetadata{}
-	mapFuncs := map[string]*types.Signature{}
+	// Names of the map functions by package path. Generated code calls them
+	// from the model's package, so they only apply to models in the same package.
+	mapFuncs := map[string][]string{}
+	modelPkgs := map[string]string{}
 
-	p.pkg = pkgs[0]
-	for _, name := range p.pkg.Types.Scope().Names() {
-		obj := p.pkg.Types.Scope().Lookup(name)
-		if obj == nil {
-			return nil, fmt.Errorf("%s.%s not found", p.pkg.Types.Path(), name)
-		}
+	for _, pkg := range pkgs {
+		for _, name := range pkg.Types.Scope().Names() {
+			obj := pkg.Types.Scope().Lookup(name)
+			if obj == nil {
+				return nil, fmt.Errorf("%s.%s not found", pkg.Types.Path(), name)
+			}
 
-		if !obj.Exported() {
-			continue
-		}
+			if !obj.Exported() {
+				continue
+			}
 
-		if sig, ok := obj.Type().(*types.Signature); ok && strings.HasPrefix(name, "Map") {
-			mapFuncs[name] = sig
-		}
+			if _, ok := obj.Type().(*types.Signature); ok && strings.HasPrefix(name, "Map") {
+				mapFuncs[pkg.PkgPath] = append(mapFuncs[pkg.PkgPath], name)
+			}
+
+			metadata, err := p.parseObject(obj.Type())
+			if err != nil {
+				return nil, err
+			}
+			if metadata == nil {
+				continue
+			}
+
+			// Generated names don't include the package, so they must be unique
+			if other, ok := modelPkgs[name]; ok {
+				return nil, fmt.Errorf("model %v is defined in both %v and %v", name, other, pkg.PkgPath)
+			}
+			modelPkgs[name] = pkg.PkgPath
 
-		metadata, err := p.parseObject(obj.Type())
-		if err != nil {
-			return nil, err
-		}
-		if metadata != nil {
 			metadatas = append(metadatas, metadata)
 		}
 	}
 
-	fieldMap := map[string]*entity.GormModelField{}
+	// Fields by package path, then model and field name
+	fieldMap := map[string]map[string]*entity.GormModelField{}
 	for _, metadata := range metadatas {
+		if fieldMap[metadata.Package] == nil {
+			fieldMap[metadata.Package] = map[string]*entity.GormModelField{}
+		}
 		for _, field := range metadata.AllFields() {
 			key := metadata.Name + field.Name
-			fieldMap[key] = field
+			fieldMap[metadata.Package][key] = field
 		}
 	}
 
-	for name := range mapFuncs {
-		name := name
+	for pkgPath, names := range mapFuncs {
+		fields := fieldMap[pkgPath]
+		for _, name := range names {
+			name := name
 
-		key, _ := strings.CutPrefix(name, "MapApi")
-		if field, ok := fieldMap[key]; ok && field.MapApiFunc == nil {
-			field.MapApiFunc = &name
-			continue
-		}
+			key, _ := strings.CutPrefix(name, "MapApi")
+			if field, ok := fields[key]; ok && field.MapApiFunc == nil {
+				field.MapApiFunc = &name
+				continue
+			}
 
-		key, _ = strings.CutPrefix(key, "Map")
-		if field, ok := fieldMap[key]; ok && field.MapFunc == nil {
-			field.MapFunc = &name
+			key, _ = strings.CutPrefix(key, "Map")
+			if field, ok := fields[key]; ok && field.MapFunc == nil {
+				field.MapFunc = &name
+			}
 		}
 	}
 
@@ -137,6 +189,10 @@ func (p *parser) parseNamed(t *types.Named) *entity.GormModelMetadata {
 	case *types.Struct:
 		metadata := p.parseStruct(u)
 		metadata.Name = t.Obj().Name()
+		metadata.Description = p.docs[t.Obj().Pos()]
+		if pkg := t.Obj().Pkg(); pkg != nil {
+			metadata.Package = pkg.Path()
+		}
 		metadata.WithType(t)
 		return metadata
 	case *types.Basic, *types.Map, *types.Array, *types.Slice, *types.Interface, *types.Signature:
@@ -168,8 +224,10 @@ func (p *parser) parseStruct(t *types.Struct) *entity.GormModelMetadata {
 		modelField := &entity.GormModelField{}
 		modelField.Parent = metadata
 		modelField.Name = field.Name()
+		modelField.Description = p.docs[field.Pos()]
 		modelField.WithType(field.Type(), p.cfg.ModuleName)
 		modelField.Tag = t.Tag(i)
+		modelField.Enum = p.parseEnum(field.Type())
 		metadata.Fields = append(metadata.Fields, modelField)
 
 		if modelField.Tag != "" {
@@ -187,3 +245,109 @@ func (p *parser) parseStruct(t *types.Struct) *entity.GormModelMetadata {
 
 	return metadata
 }
+
+// Parse a named string or integer type declared in the module, along with the
+// constants of that type in its package, in the order they're declared.
+//
+// Returns nil if the type isn't one, or has no constants.
+func (p *parser) parseEnum(t types.Type) *entity.GormEnumMetadata {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsString|types.IsInteger) == 0 {
+		return nil
+	}
+
+	// Ignore types from other modules, like time.Duration
+	pkg := named.Obj().Pkg()
+	if !p.isModulePackage(pkg.Path()) {
+		return nil
+	}
+
+	consts := []*types.Const{}
+	for _, name := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	enum := &entity.GormEnumMetadata{
+		Name:        named.Obj().Name(),
+		Package:     pkg.Path(),
+		Type:        basic.Name(),
+		Description: p.docs[named.Obj().Pos()],
+		Values:      []entity.GormEnumValue{},
+	}
+	seen := map[string]bool{}
+	for _, c := range consts {
+		value := c.Val().ExactString()
+		if c.Val().Kind() == constant.String {
+			literal, _ := json.Marshal(constant.StringVal(c.Val()))
+			value = string(literal)
+		}
+		// Skip aliases for a value that was already declared
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		enum.Values = append(enum.Values, entity.GormEnumValue{Name: c.Name(), Value: value})
+	}
+	return enum
+}
+
+// Whether the package is in the module being generated for
+func (p *parser) isModulePackage(path string) bool {
+	return path == "command-line-arguments" || path == p.cfg.ModuleName || strings.HasPrefix(path, p.cfg.ModuleName+"/")
+}
+
+// Collect the doc comments of the types and struct fields in the files.
+//
+// A field's trailing comment is used if it doesn't have a doc comment.
+func (p *parser) parseDocs(files []*ast.File) {
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.GenDecl:
+				if node.Tok != token.TYPE {
+					return true
+				}
+				for _, spec := range node.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					// The doc comment of an ungrouped type is on the declaration
+					if doc == nil && !node.Lparen.IsValid() {
+						doc = node.Doc
+					}
+					p.addDoc(typeSpec.Name, doc)
+				}
+			case *ast.Field:
+				doc := node.Doc
+				if doc == nil {
+					doc = node.Comment
+				}
+				for _, name := range node.Names {
+					p.addDoc(name, doc)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func (p *parser) addDoc(name *ast.Ident, doc *ast.CommentGroup) {
+	if text := strings.TrimSpace(doc.Text()); text != "" {
+		p.docs[name.Pos()] = text
+	}
+}
@@ -3,6 +3,8 @@ package parse
 import (
 	"encoding/json"
 	"log"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/joeriddles/goalesce/pkg/config"
@@ -26,12 +28,13 @@ func TestParse_Basic(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 
 	parser := NewParser(noopLogger, cfg)
-	actual, err := parser.Parse(cfg.InputFolderPath)
+	actual, err := parser.Parse(cfg.InputFolderPaths...)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(actual))
 
 	expected := &entity.GormModelMetadata{
-		Name: "User",
+		Name:    "User",
+		Package: "github.com/joeriddles/goalesce/examples/basic/model",
 		Fields: []*entity.GormModelField{
 			{
 				Name: "Name",
@@ -47,6 +50,7 @@ func TestParse_Basic(t *testing.T) {
 		Embedded: []*entity.GormModelMetadata{
 			{
 				Name:     "Model",
+				Package:  "gorm.io/gorm",
 				Embedded: []*entity.GormModelMetadata{},
 				Fields: []*entity.GormModelField{
 					{
@@ -81,12 +85,14 @@ func TestParse_Cars(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 
 	parser := NewParser(noopLogger, cfg)
-	actual, err := parser.Parse(cfg.InputFolderPath)
+	actual, err := parser.Parse(cfg.InputFolderPaths...)
 	require.NoError(t, err)
 	assert.Equal(t, 6, len(actual))
 
 	expectedManufacturer := &entity.GormModelMetadata{
-		Name: "Manufacturer",
+		Name:        "Manufacturer",
+		Package:     "github.com/joeriddles/goalesce/examples/cars/model",
+		Description: "A vehicle manufacturer, like Chevrolet",
 		Fields: []*entity.GormModelField{
 			{
 				Name: "Name",
@@ -100,6 +106,7 @@ func TestParse_Cars(t *testing.T) {
 		Embedded: []*entity.GormModelMetadata{
 			{
 				Name:     "Model",
+				Package:  "gorm.io/gorm",
 				Embedded: []*entity.GormModelMetadata{},
 				Fields: []*entity.GormModelField{
 					{
@@ -125,7 +132,9 @@ func TestParse_Cars(t *testing.T) {
 		},
 	}
 	expectedModel := &entity.GormModelMetadata{
-		Name: "VehicleModel",
+		Name:        "VehicleModel",
+		Package:     "github.com/joeriddles/goalesce/examples/cars/model",
+		Description: "A vehicle model, like a Chevrolet Silverado",
 		Fields: []*entity.GormModelField{
 
 			{
@@ -140,6 +149,21 @@ func TestParse_Cars(t *testing.T) {
 				Name: "Manufacturer",
 				Type: "Manufacturer",
 			},
+			{
+				Name: "Drivetrain",
+				Type: "*Drivetrain",
+				Enum: &entity.GormEnumMetadata{
+					Name:        "Drivetrain",
+					Package:     "github.com/joeriddles/goalesce/examples/cars/model",
+					Type:        "int",
+					Description: "How many wheels a vehicle model drives",
+					Values: []entity.GormEnumValue{
+						{Name: "DrivetrainTwoWheel", Value: "1"},
+						{Name: "DrivetrainFourWheel", Value: "2"},
+						{Name: "DrivetrainAllWheel", Value: "3"},
+					},
+				},
+			},
 			{
 				Name: "Parts",
 				Type: "[]Part",
@@ -149,6 +173,7 @@ func TestParse_Cars(t *testing.T) {
 		Embedded: []*entity.GormModelMetadata{
 			{
 				Name:     "Model",
+				Package:  "gorm.io/gorm",
 				Embedded: []*entity.GormModelMetadata{},
 				Fields: []*entity.GormModelField{
 					{
@@ -185,6 +210,12 @@ func TestParse_Cars(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assertJsonEq(t, expectedModel, actualModel)
+
+	actualVehicle, err := utils.First(actual, func(f *entity.GormModelMetadata) bool {
+		return f.Name == "Vehicle"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "The vehicle identification number", actualVehicle.GetField("Vin").Description)
 	// TODO(joeriddles) assert all models in cars/main.go...
 }
 
@@ -194,12 +225,13 @@ func TestParse_Custom(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 
 	parser := NewParser(noopLogger, cfg)
-	actual, err := parser.Parse(cfg.InputFolderPath)
+	actual, err := parser.Parse(cfg.InputFolderPaths...)
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(actual))
 
 	expectedBase := &entity.GormModelMetadata{
 		Name:     "Base",
+		Package:  "github.com/joeriddles/goalesce/examples/custom/model",
 		Embedded: []*entity.GormModelMetadata{},
 		Fields: []*entity.GormModelField{
 			{
@@ -227,7 +259,8 @@ func TestParse_Custom(t *testing.T) {
 	assertJsonEq(t, expectedBase, &actual[0])
 
 	expectedCustom := &entity.GormModelMetadata{
-		Name: "Custom",
+		Name:    "Custom",
+		Package: "github.com/joeriddles/goalesce/examples/custom/model",
 		Fields: []*entity.GormModelField{
 			{
 				Name: "Name",
@@ -241,6 +274,43 @@ func TestParse_Custom(t *testing.T) {
 	assertJsonEq(t, expectedCustom, &actual[1])
 }
 
+func TestParse_MapFuncsInOtherPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/m\n\ngo 1.22\n",
+		"model/model.go": `package model
+
+type Widget struct {
+	Name string
+	Size int
+}
+
+func MapWidgetSize(size int) int { return size }
+`,
+		// Generated code calls map functions from the model's package, so
+		// this one can't be used for Widget.Name
+		"other/other.go": `package other
+
+func MapWidgetName(name string) string { return name }
+`,
+	}
+	for name, content := range files {
+		fp := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fp), os.ModePerm))
+		require.NoError(t, os.WriteFile(fp, []byte(content), 0o644))
+	}
+
+	parser := NewParser(noopLogger, &config.Config{ModuleName: "example.com/m"})
+	actual, err := parser.Parse(filepath.Join(dir, "model"), filepath.Join(dir, "other"))
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+
+	name, size := actual[0].Fields[0], actual[0].Fields[1]
+	assert.Nil(t, name.MapFunc)
+	require.NotNil(t, size.MapFunc)
+	assert.Equal(t, "MapWidgetSize", *size.MapFunc)
+}
+
 func assertJsonEq(t *testing.T, expected any, actual any) {
 	actualBytes, err := json.Marshal(actual)
 	require.NoError(t, err)
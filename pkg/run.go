@@ -12,7 +12,7 @@ func Run(cfg *config.Config) error {
 	logger := log.Default()
 	parser := parse.NewParser(logger, cfg)
 
-	metadatas, err := parser.Parse(cfg.InputFolderPath)
+	metadatas, err := parser.Parse(cfg.InputFolderPaths...)
 	if err != nil {
 		return err
 	}
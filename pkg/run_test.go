@@ -1,15 +1,145 @@
 package pkg
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/joeriddles/goalesce/pkg/config"
+	"github.com/joeriddles/goalesce/pkg/utils"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	gofumpt "mvdan.cc/gofumpt/format"
 )
 
-// TODO(joeriddles): assert golden files
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// Generate each example into a copy of its module and compare the generated
+// Go and YAML files with the golden files. Run with -update to refresh them.
+func Test_Golden(t *testing.T) {
+	configFiles, err := filepath.Glob("../examples/*/config.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, configFiles)
+
+	for _, configFile := range configFiles {
+		example := filepath.Base(filepath.Dir(configFile))
+		t.Run(example, func(t *testing.T) {
+			cfg, err := config.FromYamlFile(configFile)
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+
+			files := generateScratch(t, cfg)
+			assertGolden(t, filepath.Join("testdata", "golden", example), files)
+		})
+	}
+}
+
+// Generate into a copy of the config's module, returning the generated Go and
+// YAML files by their path relative to the module
+func generateScratch(t *testing.T, cfg *config.Config) map[string]string {
+	modulePath, err := utils.FindGoMod(cfg.OutputFile, cfg.ModuleName)
+	require.NoError(t, err)
+	moduleRoot := filepath.Dir(modulePath)
+
+	scratchRoot := t.TempDir()
+	require.NoError(t, copyModule(moduleRoot, scratchRoot, outputPaths(cfg)...))
+	scratchCfg := rebaseConfig(cfg, moduleRoot, scratchRoot)
+	require.NoError(t, Run(scratchCfg))
+
+	files := map[string]string{}
+	for _, dir := range []string{
+		scratchCfg.OutputFile,
+		scratchCfg.RepositoryConfiguration.OutputFile,
+		filepath.Dir(scratchCfg.TypesCodegen.OutputFile),
+		filepath.Dir(scratchCfg.ServerCodegen.OutputFile),
+	} {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if ext := filepath.Ext(path); ext != ".go" && ext != ".yaml" {
+				return nil
+			}
+			rel, err := filepath.Rel(scratchRoot, path)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			files[filepath.ToSlash(rel)] = string(data)
+			return err
+		})
+		require.NoError(t, err)
+	}
+	return files
+}
+
+// Compare the files with the golden files in dir, or replace the golden files with -update
+func assertGolden(t *testing.T, dir string, files map[string]string) {
+	if *update {
+		require.NoError(t, os.RemoveAll(dir))
+		for file, actual := range files {
+			fp := filepath.Join(dir, filepath.FromSlash(file)) + ".golden"
+			require.NoError(t, os.MkdirAll(filepath.Dir(fp), os.ModePerm))
+			require.NoError(t, os.WriteFile(fp, []byte(actual), 0o644))
+		}
+		return
+	}
+
+	goldenFiles := map[string]bool{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		goldenFiles[strings.TrimSuffix(filepath.ToSlash(rel), ".golden")] = true
+		return nil
+	})
+	if !errors.Is(err, fs.ErrNotExist) {
+		require.NoError(t, err)
+	}
+
+	for file := range goldenFiles {
+		if _, ok := files[file]; !ok {
+			t.Errorf("%v is no longer generated, run with -update to remove its golden file", file)
+		}
+	}
+
+	for file, actual := range files {
+		if !goldenFiles[file] {
+			t.Errorf("%v has no golden file, run with -update to add it", file)
+			continue
+		}
+		expected, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)) + ".golden")
+		require.NoError(t, err)
+		if string(expected) == actual {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(expected)),
+			B:        difflib.SplitLines(actual),
+			FromFile: "golden/" + file,
+			ToFile:   "actual/" + file,
+			Context:  3,
+		})
+		require.NoError(t, err)
+		t.Errorf("%v does not match its golden file, run with -update if this is expected:\n%v", file, diff)
+	}
+}
 
 func Test_Basic(t *testing.T) {
 	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
@@ -19,6 +149,203 @@ func Test_Basic(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_Check(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	err = Run(cfg)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	upToDate, err := Check(cfg, &out)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+	assert.Empty(t, out.String())
+
+	// Simulate a stale generated file
+	fp := "../examples/basic/generated/api/user_controller.gen.go"
+	code, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fp, append(code, []byte("// stale\n")...), 0o644))
+
+	upToDate, err = Check(cfg, &out)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	assert.Contains(t, out.String(), "--- a/generated/api/user_controller.gen.go")
+	assert.Contains(t, out.String(), "-// stale")
+
+	// The stale file is left as is
+	stale, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	assert.Contains(t, string(stale), "// stale")
+
+	require.NoError(t, os.WriteFile(fp, code, 0o644))
+}
+
+func Test_Check_DifferentBuildVersion(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, Run(cfg))
+
+	// Simulate output generated by a different build of goalesce
+	fp := "../examples/basic/generated/api/types.gen.go"
+	code, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	header := regexp.MustCompile(`(?m)^// Code generated by .+ DO NOT EDIT\.$`)
+	require.True(t, header.Match(code))
+	code = header.ReplaceAll(code, []byte("// Code generated by github.com/joeriddles/goalesce version v1.2.3 DO NOT EDIT."))
+	require.NoError(t, os.WriteFile(fp, code, 0o644))
+
+	manifestFile := "../examples/basic/generated/goalesce.manifest.json"
+	manifest, err := os.ReadFile(manifestFile)
+	require.NoError(t, err)
+	version := regexp.MustCompile(`(?m)^  "version": ".*",$`)
+	require.True(t, version.Match(manifest))
+	manifest = version.ReplaceAll(manifest, []byte(`  "version": "v1.2.3",`))
+	require.NoError(t, os.WriteFile(manifestFile, manifest, 0o644))
+
+	var out bytes.Buffer
+	upToDate, err := Check(cfg, &out)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+	assert.Empty(t, out.String())
+}
+
+func Test_Check_StaleRepository(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/repository/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, Run(cfg))
+
+	// Simulate the repository of a deleted model, outside of the output directory
+	fp := filepath.Join(cfg.RepositoryConfiguration.OutputFile, "deleted_repository.gen.go")
+	require.NoError(t, os.WriteFile(fp, []byte("package repository\n"), 0o644))
+	defer os.Remove(fp)
+
+	manifestFile := filepath.Join(cfg.OutputFile, "goalesce.manifest.json")
+	data, err := os.ReadFile(manifestFile)
+	require.NoError(t, err)
+	manifest := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	key, err := filepath.Rel(cfg.OutputFile, fp)
+	require.NoError(t, err)
+	manifest["files"].(map[string]any)[filepath.ToSlash(key)] = map[string]any{"output": ""}
+	data, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestFile, data, 0o644))
+
+	var out bytes.Buffer
+	upToDate, err := Check(cfg, &out)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	assert.Contains(t, out.String(), "--- a/custom/deleted_repository.gen.go")
+}
+
+func Test_Watch(t *testing.T) {
+	configFile := "../examples/basic/config.yaml"
+	cfg, err := config.FromYamlFile(configFile)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, cfg, configFile)
+	}()
+
+	// Generates on start
+	fp := "../examples/basic/generated/api/user_controller.gen.go"
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(fp)
+		return err == nil
+	}, time.Minute, 100*time.Millisecond)
+
+	// Regenerates when a model changes
+	require.NoError(t, os.Remove(fp))
+	modelFp := "../examples/basic/model/model.go"
+	model, err := os.ReadFile(modelFp)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(modelFp, model, 0o644))
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(fp)
+		return err == nil
+	}, time.Minute, 100*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func Test_Watch_IgnoresOutput(t *testing.T) {
+	cfg := &config.Config{
+		InputFolderPaths: []string{"../examples/basic/..."},
+		OutputFile:       "../examples/basic/generated",
+	}
+	require.NoError(t, os.MkdirAll("../examples/basic/generated/api", os.ModePerm))
+
+	files, inputDirs, err := watchedPaths(cfg, "")
+	require.NoError(t, err)
+	assert.True(t, inputDirs[absPath("../examples/basic/model")])
+	assert.False(t, inputDirs[absPath("../examples/basic/generated")])
+	assert.False(t, inputDirs[absPath("../examples/basic/generated/api")])
+
+	write := func(fp string) fsnotify.Event {
+		return fsnotify.Event{Name: fp, Op: fsnotify.Write}
+	}
+	outputs := watchedOutputs(cfg)
+	assert.True(t, isWatchedEvent(write("../examples/basic/model/model.go"), files, inputDirs, outputs))
+	assert.False(t, isWatchedEvent(write("../examples/basic/generated/api/user_controller.gen.go"), files, inputDirs, outputs))
+}
+
+func Test_Generate_Incremental(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	// Add a model, then remove it again
+	modelFp := "../examples/basic/model/widget.go"
+	require.NoError(t, os.WriteFile(modelFp, []byte("package model\n\nimport \"gorm.io/gorm\"\n\ntype Widget struct {\n\tgorm.Model\n\tName string\n}\n"), 0o644))
+	t.Cleanup(func() {
+		_ = os.Remove(modelFp)
+		_ = Run(cfg)
+	})
+	require.NoError(t, Run(cfg))
+
+	widgetFp := "../examples/basic/generated/api/widget_controller.gen.go"
+	_, err = os.Stat(widgetFp)
+	require.NoError(t, err)
+
+	// Files that aren't in the manifest are left alone
+	untrackedFp := "../examples/basic/generated/untracked.txt"
+	require.NoError(t, os.WriteFile(untrackedFp, []byte("untracked"), 0o644))
+	t.Cleanup(func() { _ = os.Remove(untrackedFp) })
+
+	userFp := "../examples/basic/generated/api/user_controller.gen.go"
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(userFp, modTime, modTime))
+
+	require.NoError(t, os.Remove(modelFp))
+	require.NoError(t, Run(cfg))
+
+	// Unchanged files aren't rewritten
+	info, err := os.Stat(userFp)
+	require.NoError(t, err)
+	assert.Equal(t, modTime, info.ModTime())
+
+	// Files for the removed model are removed
+	for _, fp := range []string{
+		widgetFp,
+		"../examples/basic/generated/api/widget_mapper.gen.go",
+		"../examples/basic/generated/repository/widget_repository.gen.go",
+		"../examples/basic/generated/widget.gen.yaml",
+	} {
+		_, err = os.Stat(fp)
+		assert.ErrorIs(t, err, os.ErrNotExist, fp)
+	}
+
+	_, err = os.Stat(untrackedFp)
+	assert.NoError(t, err)
+}
+
 func Test_Cars(t *testing.T) {
 	cfg, err := config.FromYamlFile("../examples/cars/config.yaml")
 	require.NoError(t, err)
@@ -27,6 +354,51 @@ func Test_Cars(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_Generate_Concurrency(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/cars/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	readOutput := func() map[string]string {
+		files := map[string]string{}
+		err := filepath.WalkDir(cfg.OutputFile, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			files[path] = string(data)
+			return err
+		})
+		require.NoError(t, err)
+		return files
+	}
+
+	// The output is the same no matter the order the models are generated in
+	cfg.Concurrency = 1
+	require.NoError(t, os.RemoveAll(cfg.OutputFile))
+	require.NoError(t, Run(cfg))
+	expected := readOutput()
+
+	cfg.Concurrency = 8
+	require.NoError(t, os.RemoveAll(cfg.OutputFile))
+	require.NoError(t, Run(cfg))
+	assert.Equal(t, expected, readOutput())
+
+	// Errors from every model are returned
+	templateFp := filepath.Join(t.TempDir(), "repository.tmpl")
+	require.NoError(t, os.WriteFile(templateFp, []byte("{{.model.Missing}}"), 0o644))
+	cfg.UserTemplates = map[string]string{"repository.tmpl": templateFp}
+	t.Cleanup(func() {
+		cfg.UserTemplates = nil
+		_ = Run(cfg)
+	})
+	err = Run(cfg)
+	require.Error(t, err)
+	for _, name := range []string{"Manufacturer", "VehicleModel", "Vehicle", "VehicleForSale", "Part", "Person"} {
+		assert.Contains(t, err.Error(), fmt.Sprintf("failed to generate %v:", name))
+	}
+}
+
 func Test_Custom(t *testing.T) {
 	cfg, err := config.FromYamlFile("../examples/custom/config.yaml")
 	require.NoError(t, err)
@@ -110,6 +482,52 @@ func Test_Generate_MultipleModelFiles(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 	err = Run(cfg)
 	require.NoError(t, err)
+
+	// Property names come from the json tags, then the naming strategy
+	user, err := os.ReadFile("../examples/multiple_files/generated/user.gen.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(user), "createdAt:")
+	assert.NotContains(t, string(user), "created_at:")
+	assert.NotContains(t, string(user), "passwordHash")
+
+	bio, err := os.ReadFile("../examples/multiple_files/generated/bio.gen.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(bio), "about:")
+	assert.NotContains(t, string(bio), "name: description")
+	assert.NotContains(t, string(bio), "- website")
+}
+
+func Test_Generate_MultiplePackages(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/multiple_packages/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	err = Run(cfg)
+	require.NoError(t, err)
+
+	// Each mapper imports its model's own package
+	mapper, err := os.ReadFile("../examples/multiple_packages/generated/api/invoice_mapper.gen.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(mapper), `"github.com/joeriddles/goalesce/examples/multiple_packages/model/fleet/billing"`)
+}
+
+func Test_Generate_Keys(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/keys/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	err = Run(cfg)
+	require.NoError(t, err)
+
+	// Composite keys have a path segment for each key
+	spec, err := os.ReadFile("../examples/keys/generated/openapi.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(spec), "/membership/{account_id}/{country_code}/:")
+	assert.Contains(t, string(spec), "operationId: GetMembershipID")
+
+	// Repository methods take the key's type
+	repository, err := os.ReadFile("../examples/keys/generated/repository/account_repository.gen.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(repository), "id uuid.UUID,")
+	assert.Contains(t, string(repository), "r.query.Account.ID.Eq(id)")
 }
 
 func Test_Generate_Pointers(t *testing.T) {
@@ -118,4 +536,54 @@ func Test_Generate_Pointers(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 	err = Run(cfg)
 	require.NoError(t, err)
+
+	// The pointers example is formatted with gofumpt
+	code, err := os.ReadFile("../examples/pointers/generated/api/user_controller.gen.go")
+	require.NoError(t, err)
+	formatted, err := gofumpt.Source(code, gofumpt.Options{ModulePath: cfg.ModuleName})
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(code))
+}
+
+func Test_Validate_QueryPackageNotFound(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	cfg.QueryPkg = "github.com/joeriddles/goalesce/examples/basic/querry"
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query_package github.com/joeriddles/goalesce/examples/basic/querry")
+}
+
+func Test_Validate_QueryPackageWithoutQuery(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	cfg.QueryPkg = "github.com/joeriddles/goalesce/examples/basic/model"
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no Query struct")
+}
+
+func Test_Validate_QueryWithoutContext(t *testing.T) {
+	cfg, err := config.FromYamlFile("../examples/basic/config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	modulePath, err := utils.FindGoMod(cfg.OutputFile, cfg.ModuleName)
+	require.NoError(t, err)
+	moduleRoot := filepath.Dir(modulePath)
+	scratchRoot := t.TempDir()
+	require.NoError(t, copyModule(moduleRoot, scratchRoot, cfg.OutputFile))
+
+	// gen.WithoutContext embeds the DAO in each query struct
+	queryFile := filepath.Join(scratchRoot, "query", "users.gen.go")
+	code, err := os.ReadFile(queryFile)
+	require.NoError(t, err)
+	code = bytes.Replace(code, []byte("\tuserDo userDo\n"), []byte("\tuserDo\n"), 1)
+	require.NoError(t, os.WriteFile(queryFile, code, 0o644))
+
+	err = rebaseConfig(cfg, moduleRoot, scratchRoot).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gen.WithoutContext")
 }
@@ -12,6 +12,23 @@ type OpenApiType struct {
 	Items    *map[string]string
 	Format   *string
 	Nullable bool
+
+	// Constraints from the field's gorm and validate tags
+	MinLength        *int
+	MaxLength        *int
+	Minimum          *float64
+	ExclusiveMinimum bool
+	Maximum          *float64
+	ExclusiveMaximum bool
+	Pattern          string
+	// JSON literals
+	Enum        []string
+	Default     string
+	Description string
+	// The Go type to generate instead of a type for the format or enum
+	GoType string
+	// If true, the Go type isn't a pointer even if the property is optional
+	SkipOptionalPointer bool
 }
 
 func (o *OpenApiType) IsSimpleType() bool {
@@ -52,6 +69,9 @@ func ToOpenApiType(typ string) *OpenApiType {
 	case "time.Time":
 		format := "date-time"
 		result = &OpenApiType{Type: "string", Format: &format}
+	case "github.com/google/uuid.UUID":
+		format := "uuid"
+		result = &OpenApiType{Type: "string", Format: &format}
 	case "gorm.io/gorm.DeletedAt":
 		format := "date-time"
 		result = &OpenApiType{Type: "string", Format: &format, Nullable: true}
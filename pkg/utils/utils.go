@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"golang.org/x/mod/modfile"
@@ -53,6 +55,21 @@ func ToHtmlCase(s string) string {
 	return strings.ReplaceAll(snakeCase, "_", "-")
 }
 
+// Convert a plural English noun to singular, like Categories to Category.
+// Nouns that don't end in s, like Children, are returned as is.
+func ToSingular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 3:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ss"):
+		return s
+	case strings.HasSuffix(s, "s"):
+		return s[:len(s)-1]
+	default:
+		return s
+	}
+}
+
 // Convert the string to PascalCase.
 func ToPascalCase(s string) string {
 	if len(s) == 0 {
@@ -143,7 +160,8 @@ func IsSimpleType(t string) bool {
 
 var goalesceTagPattern *regexp.Regexp = regexp.MustCompile(`goalesce:"(.*?)"`)
 
-// Parse goalesce settings from a field's tag
+// Parse goalesce settings from a field's tag. Settings without a value, like
+// version, map to an empty string.
 func ParseGoalesceTagSettings(tag string) (map[string]string, error) {
 	settings := map[string]string{}
 
@@ -159,12 +177,153 @@ func ParseGoalesceTagSettings(tag string) (map[string]string, error) {
 			continue
 		}
 
-		keyAndValue := strings.SplitN(kvp, ":", 2)
-		if len(keyAndValue) != 2 {
-			return nil, fmt.Errorf("cannot parse goalesce settings key-value pair: %v", kvp)
+		key, value, _ := strings.Cut(kvp, ":")
+		if key == "" {
+			return nil, fmt.Errorf("cannot parse goalesce setting: %v", kvp)
 		}
-		settings[keyAndValue[0]] = keyAndValue[1]
+		settings[key] = value
 	}
 
 	return settings, nil
 }
+
+// Parse gorm settings from a field's tag, the same way gorm does.
+//
+// Keys are upper case, like NOT NULL or SIZE, and settings without a value,
+// like primaryKey, map to their key. A ; in a value is escaped as \;.
+func ParseGormTagSettings(tag string) map[string]string {
+	settings := map[string]string{}
+
+	gormTag, ok := reflect.StructTag(tag).Lookup("gorm")
+	if !ok {
+		return settings
+	}
+
+	names := strings.Split(gormTag, ";")
+	for i := 0; i < len(names); i++ {
+		name := names[i]
+		for strings.HasSuffix(name, `\`) && i+1 < len(names) {
+			i++
+			name = name[:len(name)-1] + ";" + names[i]
+		}
+
+		keyAndValue := strings.SplitN(name, ":", 2)
+		key := strings.ToUpper(strings.TrimSpace(keyAndValue[0]))
+		if key == "" {
+			continue
+		}
+		if len(keyAndValue) == 2 {
+			settings[key] = keyAndValue[1]
+		} else {
+			settings[key] = key
+		}
+	}
+
+	return settings
+}
+
+// Parse the name and omitempty option from a field's json tag, the same way
+// encoding/json does. The name is "" if the tag doesn't set one, or - if the
+// field is ignored.
+func ParseJsonTag(tag string) (name string, omitEmpty bool) {
+	jsonTag, ok := reflect.StructTag(tag).Lookup("json")
+	if !ok {
+		return "", false
+	}
+	if jsonTag == "-" {
+		return "-", false
+	}
+
+	options := strings.Split(jsonTag, ",")
+	for _, option := range options[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return options[0], omitEmpty
+}
+
+// A bound on a column from a gorm check constraint, like age >= 18
+type CheckBound struct {
+	Column   string
+	Operator string // One of <, <=, >, or >=
+	Value    float64
+}
+
+var checkNamePattern *regexp.Regexp = regexp.MustCompile(`^\s*\w+\s*,`)
+var checkAndPattern *regexp.Regexp = regexp.MustCompile(`(?i)\s+and\s+`)
+var checkBoundPattern *regexp.Regexp = regexp.MustCompile(`^\s*(\w+)\s*(<=|>=|<|>)\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+// Parse the bounds from a gorm check constraint, like "age >= 18 AND age < 150".
+//
+// The constraint may be named, like "age_checker,age >= 18". Only comparisons
+// of a column to a number are parsed, other expressions are ignored.
+func ParseGormCheckBounds(check string) []CheckBound {
+	check = checkNamePattern.ReplaceAllString(check, "")
+
+	bounds := []CheckBound{}
+	for _, expr := range checkAndPattern.Split(check, -1) {
+		match := checkBoundPattern.FindStringSubmatch(expr)
+		if match == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[3], 64)
+		if err != nil {
+			continue
+		}
+		bounds = append(bounds, CheckBound{
+			Column:   match[1],
+			Operator: match[2],
+			Value:    value,
+		})
+	}
+	return bounds
+}
+
+// A rule from a field's validate tag, like min=3
+type ValidateRule struct {
+	Name  string
+	Param string
+}
+
+// Parse the go-playground/validator rules from a field's tag.
+//
+// Rules for the elements of a slice or map, after dive, and rules combined
+// with |, are ignored.
+func ParseValidateTagRules(tag string) []ValidateRule {
+	rules := []ValidateRule{}
+
+	validateTag, ok := reflect.StructTag(tag).Lookup("validate")
+	if !ok {
+		return rules
+	}
+
+	for _, rule := range strings.Split(validateTag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "dive" {
+			break
+		}
+		if rule == "" || strings.Contains(rule, "|") {
+			continue
+		}
+		nameAndParam := strings.SplitN(rule, "=", 2)
+		validateRule := ValidateRule{Name: nameAndParam[0]}
+		if len(nameAndParam) == 2 {
+			validateRule.Param = nameAndParam[1]
+		}
+		rules = append(rules, validateRule)
+	}
+
+	return rules
+}
+
+var oneOfPattern *regexp.Regexp = regexp.MustCompile(`'[^']*'|\S+`)
+
+// Split the values of a oneof validate rule, like "red 'dark blue'"
+func SplitOneOf(param string) []string {
+	values := []string{}
+	for _, value := range oneOfPattern.FindAllString(param, -1) {
+		values = append(values, strings.Trim(value, "'"))
+	}
+	return values
+}
@@ -31,6 +31,13 @@ func TestToPascalCase(t *testing.T) {
 	assert.Equal(t, "HelloWorld", ToPascalCase("HelloWorld"))
 }
 
+func TestToSingular(t *testing.T) {
+	assert.Equal(t, "Model", ToSingular("Models"))
+	assert.Equal(t, "Category", ToSingular("Categories"))
+	assert.Equal(t, "Address", ToSingular("Address"))
+	assert.Equal(t, "Children", ToSingular("Children"))
+}
+
 func TestStripModulePackage(t *testing.T) {
 	assert.Equal(t, "User", StripModulePackage(`github.com/joeriddles/goalesce/pkg/model.User`, `github.com/joeriddles/goalesce`))
 	assert.Equal(t, "User", StripModulePackage(`command-line-arguments.User`, `command-line-arguments`))
@@ -51,3 +58,65 @@ func Test_ParseGoalesceTagSettings(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, map[string]string{"openapi_type": "string"}, actual)
 }
+
+func Test_ParseGoalesceTagSettings_WithoutValue(t *testing.T) {
+	actual, err := ParseGoalesceTagSettings(`goalesce:"version;openapi_type:integer"`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"version": "", "openapi_type": "integer"}, actual)
+}
+
+func Test_ParseGormTagSettings_Empty(t *testing.T) {
+	actual := ParseGormTagSettings(`goalesce:"openapi_type:string"`)
+	assert.Equal(t, map[string]string{}, actual)
+}
+
+func Test_ParseGormTagSettings(t *testing.T) {
+	actual := ParseGormTagSettings(`goalesce:"openapi_type:string" gorm:"size:64;not null;uniqueIndex;default:'a\\;b';check:age_checker,age >= 18;"`)
+	assert.Equal(t, map[string]string{
+		"SIZE":        "64",
+		"NOT NULL":    "NOT NULL",
+		"UNIQUEINDEX": "UNIQUEINDEX",
+		"DEFAULT":     "'a;b'",
+		"CHECK":       "age_checker,age >= 18",
+	}, actual)
+}
+
+func Test_ParseJsonTag(t *testing.T) {
+	tests := []struct {
+		tag       string
+		name      string
+		omitEmpty bool
+	}{
+		{`gorm:"column:name"`, "", false},
+		{`json:"createdAt"`, "createdAt", false},
+		{`json:"nickname,omitempty"`, "nickname", true},
+		{`json:",omitempty"`, "", true},
+		{`json:"-"`, "-", false},
+	}
+	for _, tt := range tests {
+		name, omitEmpty := ParseJsonTag(tt.tag)
+		assert.Equal(t, tt.name, name, tt.tag)
+		assert.Equal(t, tt.omitEmpty, omitEmpty, tt.tag)
+	}
+}
+
+func Test_ParseGormCheckBounds(t *testing.T) {
+	actual := ParseGormCheckBounds("age_checker,age > 0 AND age <= 150.5 and name <> 'jinzhu'")
+	assert.Equal(t, []CheckBound{
+		{Column: "age", Operator: ">", Value: 0},
+		{Column: "age", Operator: "<=", Value: 150.5},
+	}, actual)
+}
+
+func Test_ParseValidateTagRules(t *testing.T) {
+	actual := ParseValidateTagRules(`json:"tags" validate:"required,min=1,oneof=a 'b c',email|url,dive,max=3"`)
+	assert.Equal(t, []ValidateRule{
+		{Name: "required"},
+		{Name: "min", Param: "1"},
+		{Name: "oneof", Param: "a 'b c'"},
+	}, actual)
+}
+
+func Test_SplitOneOf(t *testing.T) {
+	assert.Equal(t, []string{"red", "dark blue", "green"}, SplitOneOf("red 'dark blue' green"))
+}
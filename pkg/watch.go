@@ -0,0 +1,209 @@
+package pkg
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/joeriddles/goalesce/pkg/config"
+)
+
+// How long to wait for changes to settle before regenerating
+const watchDebounce = 250 * time.Millisecond
+
+// Generate, then regenerate whenever the models, config file, OpenAPI file, or
+// user templates change, until ctx is done.
+//
+// If configFile is set, the config is reloaded before each regeneration.
+// Parse, template, and config errors are logged instead of stopping the watch.
+func Watch(ctx context.Context, cfg *config.Config, configFile string) error {
+	logger := log.Default()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	files, inputDirs, err := watchedPaths(cfg, configFile)
+	if err != nil {
+		return err
+	}
+	if err := watchDirs(watcher, files, inputDirs); err != nil {
+		return err
+	}
+
+	regenerate := func() {
+		if configFile != "" {
+			reloaded, err := config.FromYamlFile(configFile)
+			if err != nil {
+				logger.Printf("failed to reload config: %v", err)
+				return
+			}
+			cfg = reloaded
+		}
+
+		// The watched paths may have changed, e.g. a new subpackage
+		for _, dir := range watcher.WatchList() {
+			_ = watcher.Remove(dir)
+		}
+		var err error
+		files, inputDirs, err = watchedPaths(cfg, configFile)
+		if err == nil {
+			err = watchDirs(watcher, files, inputDirs)
+		}
+		if err != nil {
+			logger.Printf("failed to watch: %v", err)
+		}
+
+		if err := Run(cfg); err != nil {
+			logger.Printf("failed to generate: %v", err)
+			return
+		}
+		logger.Printf("generated %v", cfg.OutputFile)
+	}
+
+	regenerate()
+	logger.Printf("watching %v for changes", strings.Join(cfg.InputFolderPaths, ", "))
+
+	// A stopped timer, reset on every relevant change
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isWatchedEvent(event, files, inputDirs, watchedOutputs(cfg)) {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Printf("watch error: %v", err)
+		case <-debounce.C:
+			regenerate()
+		}
+	}
+}
+
+// Get the individual files and the input directories to watch.
+//
+// Package patterns like ./model/... include every directory below them.
+func watchedPaths(cfg *config.Config, configFile string) (map[string]bool, map[string]bool, error) {
+	files := map[string]bool{}
+	for _, fp := range []string{configFile, cfg.OpenApiFile} {
+		if fp != "" {
+			files[absPath(fp)] = true
+		}
+	}
+	if cfg.RepositoryConfiguration != nil && cfg.RepositoryConfiguration.Template != nil {
+		files[absPath(*cfg.RepositoryConfiguration.Template)] = true
+	}
+	for _, fp := range cfg.UserTemplates {
+		files[absPath(fp)] = true
+	}
+
+	// Patterns like ./... can include the outputs, which would regenerate on
+	// every generation
+	outputs := watchedOutputs(cfg)
+	inputDirs := map[string]bool{}
+	for _, path := range cfg.InputFolderPaths {
+		dir := absPath(config.PackageDir(path))
+		if dir == absPath(path) {
+			inputDirs[dir] = true
+			continue
+		}
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if isOutput(path, outputs) {
+					return filepath.SkipDir
+				}
+				inputDirs[path] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return files, inputDirs, nil
+}
+
+// Watch the input directories and the parent directories of the files.
+//
+// Files are watched through their parent directory, since editors often
+// replace a file instead of writing to it, which would drop the watch.
+func watchDirs(watcher *fsnotify.Watcher, files map[string]bool, inputDirs map[string]bool) error {
+	dirs := []string{}
+	for dir := range inputDirs {
+		dirs = append(dirs, dir)
+	}
+	for fp := range files {
+		dirs = append(dirs, filepath.Dir(fp))
+	}
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Whether the event is for a Go file in an input directory or one of the
+// watched files, and not for a generated file
+func isWatchedEvent(event fsnotify.Event, files map[string]bool, inputDirs map[string]bool, outputs []string) bool {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return false
+	}
+	fp := absPath(event.Name)
+	if isOutput(fp, outputs) {
+		return false
+	}
+	if files[fp] {
+		return true
+	}
+	return inputDirs[filepath.Dir(fp)] && strings.HasSuffix(fp, ".go")
+}
+
+// Get the absolute paths of the configured outputs
+func watchedOutputs(cfg *config.Config) []string {
+	outputs := []string{}
+	for _, path := range outputPaths(cfg) {
+		if path != "" {
+			outputs = append(outputs, absPath(path))
+		}
+	}
+	return outputs
+}
+
+// Whether the path is one of the outputs or in an output directory
+func isOutput(fp string, outputs []string) bool {
+	for _, output := range outputs {
+		if fp == output || strings.HasPrefix(fp, output+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func absPath(fp string) string {
+	abs, err := filepath.Abs(fp)
+	if err != nil {
+		return fp
+	}
+	return abs
+}